@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"log"
 	"strconv"
@@ -9,23 +10,62 @@ import (
 	"github.com/valeriikundas/todoist-scripts/telegram"
 	"github.com/valeriikundas/todoist-scripts/todoist"
 	"github.com/valeriikundas/todoist-scripts/toggl"
+	"github.com/valeriikundas/todoist-scripts/utils"
 )
 
 func SendReportAboutIncorrectProjectsToTelegram(
 	todoistApiToken string,
 	telegramApiToken string,
 	telegramUserIDString string,
-	excludeFromZeroProjectsList []string,
+	checkConfig todoist.NextActionCheckConfig,
+	options ...todoist.ClientOption,
 ) (*IncorrectResponse, error) {
-	todoistClient := todoist.NewClient(todoistApiToken)
-	tooMany, zero := todoistClient.GetProjectsWithTooManyAndZeroTasks(1, excludeFromZeroProjectsList)
+	todoistClient := todoist.NewClient(todoistApiToken, options...)
+
+	var demoted []todoist.DemotedTask
+	if checkConfig.Enforcement != nil {
+		var err error
+		demoted, err = todoistClient.EnforceNextActionLimits(checkConfig, *checkConfig.Enforcement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var promoted []todoist.PromotedTask
+	if checkConfig.Promotion != nil {
+		var err error
+		promoted, err = todoistClient.PromoteNextActions(checkConfig, *checkConfig.Promotion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tooMany, zero := todoistClient.GetProjectsWithTooManyAndZeroTasks(checkConfig)
+	var lint []todoist.IncorrectProjectSchema
+	if checkConfig.Lint != nil {
+		lint = todoistClient.LintTasks(*checkConfig.Lint)
+	}
+	var projectLint []todoist.IncorrectProjectSchema
+	if checkConfig.ProjectLint != nil {
+		projectLint = todoistClient.LintProjects(*checkConfig.ProjectLint)
+	}
 	combined := IncorrectResponse{
-		TooMany: tooMany,
-		Zero:    zero,
+		TooMany:     tooMany,
+		Zero:        zero,
+		Demoted:     demoted,
+		Promoted:    promoted,
+		Lint:        lint,
+		ProjectLint: projectLint,
 	}
 
 	tg := telegram.NewTelegram(telegramApiToken)
 	message := todoistClient.PrettyOutput(tooMany, zero)
+	if len(promoted) > 0 {
+		message = todoistClient.PrettyPromotionOutput(promoted) + "\n" + message
+	}
+	if len(demoted) > 0 {
+		message = todoistClient.PrettyDemotionOutput(demoted, checkConfig.Enforcement.DemoteLabel) + "\n" + message
+	}
 	telegramUserID, err := strconv.Atoi(telegramUserIDString)
 	if err != nil {
 		return nil, err
@@ -36,18 +76,43 @@ func SendReportAboutIncorrectProjectsToTelegram(
 		return nil, err
 	}
 
+	// lint findings go in their own messages, so that a long list does not push the report over the length limit
+	if len(lint) > 0 {
+		err = tg.Send(telegramUserID, todoistClient.PrettyLintOutput(lint), telegram.ParseModeMarkdownV2)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(projectLint) > 0 {
+		err = tg.Send(telegramUserID, todoistClient.PrettyProjectLintOutput(projectLint), telegram.ParseModeMarkdownV2)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &combined, nil
 }
 
 type IncorrectResponse struct {
-	TooMany []todoist.IncorrectProjectSchema `json:"TooMany"`
-	Zero    []todoist.IncorrectProjectSchema `json:"Zero"`
+	TooMany  []todoist.IncorrectProjectSchema `json:"TooMany"`
+	Zero     []todoist.IncorrectProjectSchema `json:"Zero"`
+	Demoted  []todoist.DemotedTask            `json:"Demoted,omitempty"`
+	Promoted []todoist.PromotedTask           `json:"Promoted,omitempty"`
+	Lint     []todoist.IncorrectProjectSchema `json:"Lint,omitempty"`
+	// ProjectLint is filled when the check config enables project linting.
+	ProjectLint []todoist.IncorrectProjectSchema `json:"ProjectLint,omitempty"`
 }
 
-func ArchiveInactiveInboxTasks(todoistApiToken string) (*MoveInactiveInboxTasksResponse, error) {
-	todoist := todoist.NewClient(todoistApiToken)
-	dstProjectName, oldThreshold, dryRun := "inbox_archive", time.Hour*24*3, false
-	tasks := todoist.MoveInactiveTasks("Inbox", dstProjectName, oldThreshold, dryRun)
+// ArchiveInactiveInboxTasks moves Inbox tasks older than config.ArchiveAfterDays, except those with config.ProtectedPriority
+// or higher, to the archive project.
+func ArchiveInactiveInboxTasks(
+	todoistApiToken string,
+	config todoist.InboxMonitorConfig,
+	options ...todoist.ClientOption,
+) (*MoveInactiveInboxTasksResponse, error) {
+	todoistClient := todoist.NewClient(todoistApiToken, options...)
+	dstProjectName, dryRun := "inbox_archive", false
+	tasks := todoistClient.MoveInactiveTasks("Inbox", dstProjectName, config.ArchiveAfterDays, config.ProtectedPriority, dryRun)
 	return &MoveInactiveInboxTasksResponse{
 		Tasks: tasks,
 	}, nil
@@ -57,7 +122,112 @@ type MoveInactiveInboxTasksResponse struct {
 	Tasks []todoist.Task `json:"tasks"`
 }
 
-func AssertRunningTogglEntry(togglApiToken string, togglWorkspaceID string, telegramApiToken string, telegramUserIDString string) (*AssertToggleEntryResponse, error) {
+func GetProductivityStats(todoistApiToken string, days int, options ...todoist.ClientOption) (*todoist.ProductivityStats, error) {
+	todoistClient := todoist.NewClient(todoistApiToken, options...)
+	stats := todoistClient.GetProductivityStats(todoistClient.DaysAgo(days))
+	return &stats, nil
+}
+
+func SendProductivityStatsToTelegram(
+	todoistApiToken string,
+	telegramApiToken string,
+	telegramUserIDString string,
+	days int,
+	options ...todoist.ClientOption,
+) (*todoist.ProductivityStats, error) {
+	todoistClient := todoist.NewClient(todoistApiToken, options...)
+	stats := todoistClient.GetProductivityStats(todoistClient.DaysAgo(days))
+
+	telegramUserID, err := strconv.Atoi(telegramUserIDString)
+	if err != nil {
+		return nil, err
+	}
+
+	tg := telegram.NewTelegram(telegramApiToken)
+	err = tg.Send(telegramUserID, todoistClient.PrettyStatsOutput(stats), telegram.ParseModeMarkdownV2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+func SendInboxReminderToTelegram(
+	todoistApiToken string,
+	telegramApiToken string,
+	telegramUserIDString string,
+	config todoist.InboxMonitorConfig,
+	options ...todoist.ClientOption,
+) (*InboxReminderResponse, error) {
+	todoistClient := todoist.NewClient(todoistApiToken, options...)
+	status := todoistClient.GetInboxStatus(config)
+
+	if !status.ShouldRemind(todoistClient.Now(), config) {
+		return &InboxReminderResponse{
+			Status: status,
+			Sent:   false,
+		}, nil
+	}
+
+	telegramUserID, err := strconv.Atoi(telegramUserIDString)
+	if err != nil {
+		return nil, err
+	}
+
+	tg := telegram.NewTelegram(telegramApiToken)
+	err = tg.Send(telegramUserID, todoistClient.PrettyInboxOutput(status), telegram.ParseModeMarkdownV2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InboxReminderResponse{
+		Status: status,
+		Sent:   true,
+	}, nil
+}
+
+type InboxReminderResponse struct {
+	Status todoist.InboxStatus `json:"status"`
+	Sent   bool                `json:"sent"`
+}
+
+// TimezoneOptions returns the client option for a configured timezone such as "Europe/Kyiv".
+// An empty timezone returns no options, so clients load it from Todoist user settings.
+func TimezoneOptions(timezone string) ([]todoist.ClientOption, error) {
+	if timezone == "" {
+		return nil, nil
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+	return []todoist.ClientOption{todoist.WithTimezone(location)}, nil
+}
+
+// UserLocation returns the configured timezone or, if it is empty, the one from Todoist user settings.
+func UserLocation(todoistApiToken string, timezone string) (*time.Location, error) {
+	if timezone != "" {
+		return time.LoadLocation(timezone)
+	}
+	return todoist.NewClient(todoistApiToken).Location(), nil
+}
+
+func AssertRunningTogglEntry(
+	togglApiToken string,
+	togglWorkspaceID string,
+	telegramApiToken string,
+	telegramUserIDString string,
+	location *time.Location,
+	workingHours utils.WorkingHours,
+	startOptions toggl.StartOptions,
+) (*AssertToggleEntryResponse, error) {
+	if !workingHours.Contains(time.Now().In(location)) {
+		return &AssertToggleEntryResponse{
+			Reason: ReasonOutsideWorkingHours,
+		}, nil
+	}
+
 	telegramUserID, err := strconv.Atoi(telegramUserIDString)
 	if err != nil {
 		return nil, err
@@ -70,6 +240,11 @@ func AssertRunningTogglEntry(togglApiToken string, togglWorkspaceID string, tele
 				Reason: ReasonTimeout,
 			}, nil
 		}
+		if errors.Is(err, toggl.ErrRepliesHandledByBot) {
+			return &AssertToggleEntryResponse{
+				Reason: ReasonPrompted,
+			}, nil
+		}
 
 		return nil, err
 	}
@@ -82,9 +257,20 @@ func AssertRunningTogglEntry(togglApiToken string, togglWorkspaceID string, tele
 		}, nil
 	}
 
-	togglClient := toggl.NewToggl(togglApiToken)
+	workspaceID, err := strconv.ParseInt(togglWorkspaceID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
 
-	err = togglClient.StartTimeEntry(timeEntry, togglWorkspaceID)
+	togglClient := toggl.NewToggl(togglApiToken)
+	_, err = togglClient.StartTimeEntry(context.Background(), workspaceID, timeEntry, startOptions)
+	if errors.Is(err, toggl.ErrNameNotFound) || errors.Is(err, toggl.ErrAmbiguousName) {
+		tg := telegram.NewTelegram(telegramApiToken)
+		sendErr := tg.Send(telegramUserID, "could not start Toggl entry: "+err.Error(), telegram.ParseModeNone)
+		if sendErr != nil {
+			log.Printf("error sending message, %v", sendErr)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +278,7 @@ func AssertRunningTogglEntry(togglApiToken string, togglWorkspaceID string, tele
 	log.Printf("Toggl: started time entry %v", timeEntry)
 	return &AssertToggleEntryResponse{
 		Reason:    ReasonUserStarted,
-		TimeEntry: timeEntry,
+		TimeEntry: timeEntry.Text,
 	}, nil
 }
 
@@ -107,4 +293,8 @@ const (
 	ReasonRunning     Reason = "running"
 	ReasonUserStarted Reason = "user-started"
 	ReasonTimeout     Reason = "timeout"
+	// ReasonPrompted means the reply is handled by the Telegram bot, which polls updates.
+	ReasonPrompted Reason = "prompted"
+
+	ReasonOutsideWorkingHours Reason = "outside-working-hours"
 )
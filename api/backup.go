@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/valeriikundas/todoist-scripts/backup"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+)
+
+func BackupTodoist(todoistApiToken string, store backup.Store) (*BackupResponse, error) {
+	todoistClient := todoist.NewClient(todoistApiToken)
+	snapshot := todoistClient.TakeSnapshot()
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	name := todoist.SnapshotName(snapshot)
+	err = store.Save(name, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackupResponse{
+		Name:         name,
+		ProjectCount: len(snapshot.Projects),
+		TaskCount:    len(snapshot.Tasks),
+	}, nil
+}
+
+type BackupResponse struct {
+	Name         string `json:"name"`
+	ProjectCount int    `json:"projectCount"`
+	TaskCount    int    `json:"taskCount"`
+}
+
+// DiffTodoistSnapshots compares two saved snapshots. With empty names the two latest snapshots are compared.
+func DiffTodoistSnapshots(store backup.Store, olderName, newerName string) (*todoist.SnapshotDiff, error) {
+	if olderName == "" || newerName == "" {
+		names, err := store.List()
+		if err != nil {
+			return nil, err
+		}
+		if len(names) < 2 {
+			return nil, errors.New("need at least two snapshots to compare")
+		}
+		olderName, newerName = names[len(names)-2], names[len(names)-1]
+	}
+
+	older, err := loadSnapshot(store, olderName)
+	if err != nil {
+		return nil, err
+	}
+	newer, err := loadSnapshot(store, newerName)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := todoist.DiffSnapshots(older, newer)
+	return &diff, nil
+}
+
+func loadSnapshot(store backup.Store, name string) (*todoist.Snapshot, error) {
+	b, err := store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	return todoist.ParseSnapshot(b)
+}
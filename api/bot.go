@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/valeriikundas/todoist-scripts/backup"
+	"github.com/valeriikundas/todoist-scripts/telegram"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+	"github.com/valeriikundas/todoist-scripts/toggl"
+)
+
+type BotConfig struct {
+	TodoistApiToken  string
+	TemplatesDir     string
+	TelegramApiToken string
+	TelegramUserID   string
+	// Timer is also used to start entries from replies to the missing Toggl entry prompt.
+	Timer TimerConfig
+	// Store keeps projects kept with the Keep button of ProposeArchivingDormantProjects, which uses the same store.
+	Store backup.Store
+}
+
+// HandleTelegramMessage runs the bot command in the message and returns the reply text.
+// Replies to the missing Toggl entry prompt start the entry, other messages that are not commands are added
+// to the Todoist Inbox.
+func HandleTelegramMessage(config BotConfig, message telegram.Message) (string, error) {
+	text := strings.TrimSpace(message.Text)
+	if toggl.IsPromptReply(message) {
+		return handleTogglPromptReply(config, text)
+	}
+	if !strings.HasPrefix(text, "/") {
+		return handleQuickCapture(config, text)
+	}
+
+	command, args, _ := strings.Cut(text, " ")
+
+	switch command {
+	case "/template":
+		return handleTemplateCommand(config, strings.Fields(args))
+	case "/timer":
+		return handleTimerCommand(config, args)
+	default:
+		return telegram.EscapeText("unknown command. available commands: /template, /timer. send any other text to add it to the Inbox"), nil
+	}
+}
+
+// handleQuickCapture adds the text as a task, see todoist.Client.QuickAddTask for supported tokens.
+func handleQuickCapture(config BotConfig, text string) (string, error) {
+	todoistClient := todoist.NewClient(config.TodoistApiToken)
+	result, err := todoistClient.QuickAddTask(text)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"added [%s](%s) to %s",
+		telegram.EscapeText(result.Task.Content),
+		result.URL,
+		telegram.EscapeText(result.ProjectName),
+	), nil
+}
+
+// handleTogglPromptReply starts the entry asked for by toggl.AskForTogglEntryInTelegram.
+func handleTogglPromptReply(config BotConfig, text string) (string, error) {
+	workspaceID, err := strconv.ParseInt(config.Timer.TogglWorkspaceID, 10, 64)
+	if err != nil {
+		return "", err
+	}
+
+	input := toggl.ParseTimeEntryInput(text)
+	togglClient := toggl.NewToggl(config.Timer.TogglApiToken)
+	_, err = togglClient.StartTimeEntry(context.Background(), workspaceID, input, config.Timer.StartOptions)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("started Toggl entry: %s", telegram.EscapeText(input.String())), nil
+}
+
+// handleTemplateCommand handles `/template <name> [Key=value ...]`. Like other replies, the returned text is MarkdownV2.
+func handleTemplateCommand(config BotConfig, args []string) (string, error) {
+	if len(args) == 0 {
+		return telegram.EscapeText("usage: /template <name> [Key=value ...]"), nil
+	}
+
+	vars := map[string]string{}
+	for _, arg := range args[1:] {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Sprintf("invalid variable `%s`, expected %s", telegram.EscapeText(arg), telegram.EscapeText("Key=value")), nil
+		}
+		vars[key] = value
+	}
+
+	result, err := InstantiateProjectTemplate(config.TodoistApiToken, config.TemplatesDir, args[0], vars, false)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"created project from template %s: [open](https://todoist.com/showProject?id=%s)",
+		telegram.EscapeText(args[0]),
+		result.ProjectID,
+	), nil
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+)
+
+type DelegationResponse struct {
+	Delegated    []todoist.AssigneeTasks      `json:"delegated"`
+	AssignedToMe []todoist.AssignedTaskSchema `json:"assignedToMe"`
+}
+
+func GetDelegatedTasks(todoistApiToken string, options ...todoist.ClientOption) (*DelegationResponse, error) {
+	todoistClient := todoist.NewClient(todoistApiToken, options...)
+
+	delegated, err := todoistClient.GetDelegatedTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	assignedToMe, err := todoistClient.GetTasksAssignedToMe()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DelegationResponse{
+		Delegated:    delegated,
+		AssignedToMe: assignedToMe,
+	}, nil
+}
+
+// SendDelegationDigestToTelegram sends one message per person with tasks delegated to them,
+// followed by tasks assigned to the user.
+func SendDelegationDigestToTelegram(
+	todoistApiToken string,
+	telegramApiToken string,
+	telegramUserIDString string,
+	options ...todoist.ClientOption,
+) (*DelegationResponse, error) {
+	resp, err := GetDelegatedTasks(todoistApiToken, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	telegramUserID, err := strconv.Atoi(telegramUserIDString)
+	if err != nil {
+		return nil, err
+	}
+
+	todoistClient := todoist.NewClient(todoistApiToken, options...)
+	tg := telegram.NewTelegram(telegramApiToken)
+	for _, group := range resp.Delegated {
+		err = tg.Send(telegramUserID, todoistClient.PrettyDelegatedOutput(group), telegram.ParseModeMarkdownV2)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(resp.AssignedToMe) > 0 {
+		err = tg.Send(telegramUserID, todoistClient.PrettyAssignedToMeOutput(resp.AssignedToMe), telegram.ParseModeMarkdownV2)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
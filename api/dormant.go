@@ -0,0 +1,164 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valeriikundas/todoist-scripts/backup"
+	"github.com/valeriikundas/todoist-scripts/telegram"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+)
+
+// DefaultDormantAfterDays is for how many calendar days a project must be inactive before it is proposed for archiving.
+const DefaultDormantAfterDays = 30
+
+const (
+	archiveProjectCallback = "archive_project"
+	keepProjectCallback    = "keep_project"
+)
+
+// dormantProjectsStateName is the store file with kept projects and when projects were first seen.
+const dormantProjectsStateName = "dormant_projects.json"
+
+// DormantProjectsState is what the proposals remember between runs and share with the Keep button.
+type DormantProjectsState struct {
+	KeptProjectIDs []string             `json:"keptProjectIDs"`
+	FirstSeen      map[string]time.Time `json:"firstSeen"`
+}
+
+type DormantProjectsResponse struct {
+	Projects []todoist.DormantProject `json:"projects"`
+}
+
+// ProposeArchivingDormantProjects sends a Telegram message with Archive and Keep buttons for every dormant project.
+// Button presses are handled by HandleTelegramCallback, which must be given the same store.
+func ProposeArchivingDormantProjects(
+	todoistApiToken string,
+	telegramApiToken string,
+	telegramUserIDString string,
+	config todoist.DormantProjectsConfig,
+	store backup.Store,
+	options ...todoist.ClientOption,
+) (*DormantProjectsResponse, error) {
+	telegramUserID, err := strconv.Atoi(telegramUserIDString)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := loadDormantProjectsState(store)
+	if err != nil {
+		return nil, err
+	}
+	config.KeptProjectIDs = append(config.KeptProjectIDs, state.KeptProjectIDs...)
+	config.FirstSeen = state.FirstSeen
+
+	todoistClient := todoist.NewClient(todoistApiToken, options...)
+	projects := todoistClient.GetDormantProjects(config)
+
+	// first seen times are saved before sending, so a failed send does not make projects look new again
+	err = saveDormantProjectsState(store, state)
+	if err != nil {
+		return nil, err
+	}
+
+	tg := telegram.NewTelegram(telegramApiToken)
+	for _, project := range projects {
+		buttons := [][]telegram.InlineButton{{
+			{Text: "Archive", CallbackData: archiveProjectCallback + ":" + project.ProjectID},
+			{Text: "Keep", CallbackData: keepProjectCallback + ":" + project.ProjectID},
+		}}
+		err = tg.SendWithButtons(telegramUserID, todoistClient.PrettyDormantProjectOutput(project), telegram.ParseModeMarkdownV2, buttons)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &DormantProjectsResponse{Projects: projects}, nil
+}
+
+// HandleTelegramCallback runs the action of a pressed inline button and returns the reply as plain text,
+// as it is also shown as the callback query notification.
+func HandleTelegramCallback(config BotConfig, callback telegram.CallbackQuery) (string, error) {
+	action, id, _ := strings.Cut(callback.Data, ":")
+
+	switch action {
+	case archiveProjectCallback:
+		todoistClient := todoist.NewClient(config.TodoistApiToken)
+		err := todoistClient.ArchiveProject(id)
+		if err != nil {
+			return "", err
+		}
+		return "archived the project", nil
+	case keepProjectCallback:
+		err := keepDormantProject(config.Store, id)
+		if err != nil {
+			return "", err
+		}
+		return "kept the project, it will not be proposed again", nil
+	case startTimerCallback:
+		response, err := StartTimerForTask(config.TodoistApiToken, config.Timer, id)
+		if err != nil {
+			return "", err
+		}
+		return prettyStartTimerOutput(*response), nil
+	default:
+		return "", fmt.Errorf("unknown callback `%s`", callback.Data)
+	}
+}
+
+// keepDormantProject stops proposals to archive the project.
+func keepDormantProject(store backup.Store, projectID string) error {
+	if store == nil {
+		return errors.New("no store to remember kept projects in")
+	}
+
+	state, err := loadDormantProjectsState(store)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(state.KeptProjectIDs, projectID) {
+		return nil
+	}
+	state.KeptProjectIDs = append(state.KeptProjectIDs, projectID)
+	return saveDormantProjectsState(store, state)
+}
+
+func loadDormantProjectsState(store backup.Store) (*DormantProjectsState, error) {
+	state := &DormantProjectsState{KeptProjectIDs: []string{}, FirstSeen: map[string]time.Time{}}
+
+	b, err := store.Load(dormantProjectsStateName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		// like for the Toggl project mapping, a missing file is told apart by the listing
+		names, listErr := store.List()
+		if listErr != nil || slices.Contains(names, dormantProjectsStateName) {
+			return nil, err
+		}
+		return state, nil
+	}
+
+	err = json.Unmarshal(b, state)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", dormantProjectsStateName, err)
+	}
+	if state.FirstSeen == nil {
+		state.FirstSeen = map[string]time.Time{}
+	}
+	return state, nil
+}
+
+func saveDormantProjectsState(store backup.Store, state *DormantProjectsState) error {
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.Save(dormantProjectsStateName, b)
+}
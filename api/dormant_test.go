@@ -0,0 +1,35 @@
+package api
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/valeriikundas/todoist-scripts/backup"
+)
+
+func TestKeepDormantProject(t *testing.T) {
+	store := backup.NewLocalStore(t.TempDir())
+
+	state, err := loadDormantProjectsState(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.KeptProjectIDs) != 0 || state.FirstSeen == nil {
+		t.Errorf("state without a file = %+v, want an empty state", state)
+	}
+
+	for _, id := range []string{"1", "2", "1"} {
+		err = keepDormantProject(store, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state, err = loadDormantProjectsState(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1", "2"}; !slices.Equal(state.KeptProjectIDs, want) {
+		t.Errorf("kept = %v, want %v", state.KeptProjectIDs, want)
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/valeriikundas/todoist-scripts/todoist"
+)
+
+// InstantiateProjectTemplate creates a project from `<templatesDir>/<templateName>.yaml`.
+// A `Date` variable in YYYY-MM-DD format sets the date relative due dates are counted from.
+func InstantiateProjectTemplate(
+	todoistApiToken string,
+	templatesDir string,
+	templateName string,
+	vars map[string]string,
+	dryRun bool,
+) (*todoist.TemplateResult, error) {
+	if templateName == "" || strings.ContainsAny(templateName, `/\.`) {
+		return nil, fmt.Errorf("invalid template name `%s`", templateName)
+	}
+
+	todoistClient := todoist.NewClient(todoistApiToken)
+	date := todoistClient.DaysAgo(0)
+	if dateString, ok := vars["Date"]; ok {
+		var err error
+		date, err = time.Parse(time.DateOnly, dateString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Date variable: %w", err)
+		}
+	}
+
+	templatePath := filepath.Join(templatesDir, templateName+".yaml")
+	projectTemplate, err := todoist.LoadProjectTemplate(templatePath, date, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return todoistClient.InstantiateTemplate(projectTemplate, date, dryRun)
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+	"github.com/valeriikundas/todoist-scripts/toggl"
+)
+
+const startTimerCallback = "start_timer"
+
+// timerTaskTagPrefix prefixes the Todoist task ID in the tag of started entries, e.g. "todoist-123".
+const timerTaskTagPrefix = "todoist-"
+
+// maxTimerButtons keeps the proposal message readable, Telegram allows up to 100 buttons.
+const maxTimerButtons = 20
+
+// TimerConfig configures starting Toggl entries from Todoist tasks.
+type TimerConfig struct {
+	TogglApiToken    string
+	TogglWorkspaceID string
+	// ProjectMap maps Todoist projects, by name or path, to Toggl project names.
+	// Subprojects use the mapping of their closest mapped ancestor, unmapped projects start entries without project.
+	ProjectMap   map[string]string
+	StartOptions toggl.StartOptions
+	// TogglOptions are passed to toggl.NewToggl, e.g. toggl.WithBaseURL in tests.
+	TogglOptions []toggl.Option
+}
+
+type StartTimerResponse struct {
+	TaskID       string `json:"taskID"`
+	Description  string `json:"description"`
+	TogglProject string `json:"togglProject,omitempty"`
+	TimeEntryID  int64  `json:"timeEntryID"`
+}
+
+// StartTimerForTask starts a Toggl entry described by the task content, tagged with the task ID.
+func StartTimerForTask(todoistApiToken string, config TimerConfig, taskID string) (*StartTimerResponse, error) {
+	ctx := context.Background()
+	todoistClient := todoist.NewClient(todoistApiToken)
+	task, err := todoistClient.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return startTimer(ctx, todoistClient, config, *task)
+}
+
+// StartTimerForFilter starts a Toggl entry for the first task matching a Todoist filter query.
+func StartTimerForFilter(todoistApiToken string, config TimerConfig, query string) (*StartTimerResponse, error) {
+	ctx := context.Background()
+	todoistClient := todoist.NewClient(todoistApiToken)
+	tasks, err := todoistClient.FilterTasks(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no tasks match `%s`", query)
+	}
+
+	return startTimer(ctx, todoistClient, config, tasks[0])
+}
+
+// StartTimerForTaskOrFilter treats arg as a task ID and, if there is no such task, as a filter query,
+// so that both `/timer 123` and `/timer today` work.
+func StartTimerForTaskOrFilter(todoistApiToken string, config TimerConfig, arg string) (*StartTimerResponse, error) {
+	ctx := context.Background()
+	todoistClient := todoist.NewClient(todoistApiToken)
+	task, err := todoistClient.GetTask(ctx, arg)
+	if err != nil {
+		// invalid IDs are rejected with 400 rather than 404, so any error falls back to the filter
+		tasks, filterErr := todoistClient.FilterTasks(ctx, arg)
+		if filterErr != nil {
+			return nil, errors.Join(err, filterErr)
+		}
+		if len(tasks) == 0 {
+			return nil, fmt.Errorf("no task has ID `%s` and no tasks match it as a filter", arg)
+		}
+		task = &tasks[0]
+	}
+
+	return startTimer(ctx, todoistClient, config, *task)
+}
+
+func startTimer(ctx context.Context, todoistClient *todoist.Client, config TimerConfig, task todoist.Task) (*StartTimerResponse, error) {
+	workspaceID, err := strconv.ParseInt(config.TogglWorkspaceID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := todoistClient.ListProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	togglProject := mapTogglProject(todoist.NewProjectTree(projects), task.ProjectID, config.ProjectMap)
+
+	// ProjectMap values are names from config, so they are not fuzzy matched
+	startOptions := config.StartOptions
+	startOptions.ExactNames = true
+
+	togglClient := toggl.NewToggl(config.TogglApiToken, config.TogglOptions...)
+	entry, err := togglClient.StartTimeEntry(ctx, workspaceID, toggl.TimeEntryInput{
+		Text:        task.Content,
+		Description: task.Content,
+		ProjectName: togglProject,
+		RawTags:     []string{timerTaskTagPrefix + task.ID},
+	}, startOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StartTimerResponse{
+		TaskID:       task.ID,
+		Description:  task.Content,
+		TogglProject: togglProject,
+		TimeEntryID:  entry.ID,
+	}, nil
+}
+
+func mapTogglProject(tree *todoist.ProjectTree, projectID string, projectMap map[string]string) string {
+	for node := tree.ByID[projectID]; node != nil; node = node.Parent {
+		if togglProject, ok := projectMap[node.Path]; ok {
+			return togglProject
+		}
+		if togglProject, ok := projectMap[node.Name]; ok {
+			return togglProject
+		}
+	}
+	return ""
+}
+
+type TimerProposalResponse struct {
+	Tasks []todoist.Task `json:"tasks"`
+}
+
+// ProposeTimerForNextActions sends a Telegram message with a button per @next_action task.
+// Button presses are handled by HandleTelegramCallback.
+func ProposeTimerForNextActions(todoistApiToken string, telegramApiToken string, telegramUserIDString string) (*TimerProposalResponse, error) {
+	todoistClient := todoist.NewClient(todoistApiToken)
+	tasks, err := todoistClient.ListTasks(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	nextActions := make([]todoist.Task, 0)
+	for _, task := range tasks {
+		if task.IsNextAction() && len(nextActions) < maxTimerButtons {
+			nextActions = append(nextActions, task)
+		}
+	}
+
+	telegramUserID, err := strconv.Atoi(telegramUserIDString)
+	if err != nil {
+		return nil, err
+	}
+
+	message := "no @next_action tasks to start a timer for"
+	buttons := make([][]telegram.InlineButton, 0, len(nextActions))
+	if len(nextActions) > 0 {
+		message = "start a Toggl timer for:"
+	}
+	for _, task := range nextActions {
+		buttons = append(buttons, []telegram.InlineButton{
+			{Text: task.Content, CallbackData: startTimerCallback + ":" + task.ID},
+		})
+	}
+
+	tg := telegram.NewTelegram(telegramApiToken)
+	err = tg.SendWithButtons(telegramUserID, message, telegram.ParseModeMarkdownV2, buttons)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TimerProposalResponse{Tasks: nextActions}, nil
+}
+
+// handleTimerCommand handles `/timer`, `/timer <task id>` and `/timer <filter>`, see StartTimerForTaskOrFilter.
+func handleTimerCommand(config BotConfig, args string) (string, error) {
+	args = strings.TrimSpace(args)
+	if args == "" {
+		response, err := ProposeTimerForNextActions(config.TodoistApiToken, config.TelegramApiToken, config.TelegramUserID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("listed %d @next_action tasks", len(response.Tasks)), nil
+	}
+
+	response, err := StartTimerForTaskOrFilter(config.TodoistApiToken, config.Timer, args)
+	if err != nil {
+		return "", err
+	}
+	return telegram.EscapeText(prettyStartTimerOutput(*response)), nil
+}
+
+// prettyStartTimerOutput is plain text, as it is also used for callback replies.
+// Bot command replies escape it before it is sent as MarkdownV2.
+func prettyStartTimerOutput(response StartTimerResponse) string {
+	reply := fmt.Sprintf("started Toggl timer for %s", response.Description)
+	if response.TogglProject != "" {
+		reply += fmt.Sprintf(" in %s", response.TogglProject)
+	}
+	return reply
+}
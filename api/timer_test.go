@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/valeriikundas/todoist-scripts/todoist"
+	"github.com/valeriikundas/todoist-scripts/toggl"
+)
+
+func TestMapTogglProject(t *testing.T) {
+	tree := todoist.NewProjectTree([]todoist.Project{
+		{ID: "areas", Name: "Areas"},
+		{ID: "work", Name: "Work", ParentID: "areas"},
+		{ID: "acme", Name: "Acme", ParentID: "work"},
+		{ID: "health", Name: "Health", ParentID: "areas"},
+		{ID: "home", Name: "Home"},
+	})
+	projectMap := map[string]string{
+		"Work":         "Client Work",
+		"Areas/Health": "Health",
+		"Acme":         "Acme Corp",
+	}
+
+	tests := []struct {
+		projectID string
+		want      string
+	}{
+		{projectID: "work", want: "Client Work"},
+		{projectID: "acme", want: "Acme Corp"},
+		{projectID: "health", want: "Health"},
+		{projectID: "areas", want: ""},
+		{projectID: "home", want: ""},
+		{projectID: "missing", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := mapTogglProject(tree, tt.projectID, projectMap); got != tt.want {
+			t.Errorf("mapTogglProject(%s) = %q, want %q", tt.projectID, got, tt.want)
+		}
+	}
+}
+
+func TestStartTimer(t *testing.T) {
+	var created toggl.CreateTimeEntryRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v9/workspaces/7/projects":
+			fmt.Fprint(w, `[{"id": 11, "name": "Client Work", "active": true}, {"id": 12, "name": "Client Workshop", "active": true}]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v9/workspaces/7/time_entries":
+			err := json.NewDecoder(r.Body).Decode(&created)
+			if err != nil {
+				t.Error(err)
+			}
+			fmt.Fprint(w, `{"id": 99}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	todoistClient := todoist.NewClientWithAPI(&todoist.MemoryAPI{
+		Projects: []todoist.Project{
+			{ID: "work", Name: "Work"},
+			{ID: "acme", Name: "Acme", ParentID: "work"},
+		},
+	})
+	config := TimerConfig{
+		// the name cache is shared between clients with the same token
+		TogglApiToken:    t.Name(),
+		TogglWorkspaceID: "7",
+		ProjectMap:       map[string]string{"Work": "Client Work"},
+		TogglOptions:     []toggl.Option{toggl.WithBaseURL(server.URL), toggl.WithHTTPClient(server.Client())},
+	}
+	task := todoist.Task{ID: "123", ProjectID: "acme", Content: "Write report"}
+
+	response, err := startTimer(context.Background(), todoistClient, config, task)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := StartTimerResponse{TaskID: "123", Description: "Write report", TogglProject: "Client Work", TimeEntryID: 99}
+	if *response != want {
+		t.Errorf("response = %+v, want %+v", *response, want)
+	}
+	if created.ProjectID != 11 {
+		t.Errorf("project id = %d, want the exactly named project 11", created.ProjectID)
+	}
+	if !slices.Equal(created.Tags, []string{"todoist-123"}) {
+		t.Errorf("tags = %v, want [todoist-123]", created.Tags)
+	}
+	if created.Description != "Write report" || created.Duration != -1 {
+		t.Errorf("entry = %+v, want a running entry described by the task", created)
+	}
+}
@@ -0,0 +1,400 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/valeriikundas/todoist-scripts/backup"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+	"github.com/valeriikundas/todoist-scripts/toggl"
+)
+
+// togglProjectMappingName is the store file with Toggl project IDs created for Todoist projects.
+const togglProjectMappingName = "toggl_projects.json"
+
+// TogglProjectSyncConfig selects the Todoist projects mirrored into Toggl.
+type TogglProjectSyncConfig struct {
+	TogglApiToken    string
+	TogglWorkspaceID string
+	// Areas are parent Todoist projects, by name or path, whose subprojects are mirrored.
+	Areas []string
+	// Labels mirror Todoist projects with an active task carrying any of them.
+	Labels []string
+	// DryRun only plans the actions, neither Toggl nor the stored mapping are changed.
+	DryRun bool
+}
+
+// ParseTogglProjectSyncConfig reads comma separated areas and labels.
+func ParseTogglProjectSyncConfig(togglApiToken, togglWorkspaceID, areas, labels string, dryRun bool) TogglProjectSyncConfig {
+	return TogglProjectSyncConfig{
+		TogglApiToken:    togglApiToken,
+		TogglWorkspaceID: togglWorkspaceID,
+		Areas:            splitNames(areas),
+		Labels:           splitNames(labels),
+		DryRun:           dryRun,
+	}
+}
+
+func splitNames(s string) []string {
+	names := make([]string, 0)
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// TogglProjectMapping is the stored link between mirrored Todoist projects and their Toggl projects.
+// Only Toggl projects in it are ever changed by SyncTogglProjects.
+type TogglProjectMapping struct {
+	// Projects maps Todoist project IDs to Toggl project IDs.
+	Projects map[string]int64 `json:"projects"`
+}
+
+type TogglProjectActionType string
+
+const (
+	TogglProjectCreate  TogglProjectActionType = "create"
+	TogglProjectRename  TogglProjectActionType = "rename"
+	TogglProjectArchive TogglProjectActionType = "archive"
+	// TogglProjectRestore reactivates the archived Toggl project of a Todoist project that is mirrored again.
+	TogglProjectRestore TogglProjectActionType = "restore"
+	// TogglProjectSkip is planned when the name is taken by a Toggl project outside the mirrored set
+	// or by another mirrored Todoist project.
+	TogglProjectSkip TogglProjectActionType = "skip"
+)
+
+type TogglProjectAction struct {
+	Type             TogglProjectActionType `json:"type"`
+	TodoistProjectID string                 `json:"todoistProjectID"`
+	TogglProjectID   int64                  `json:"togglProjectID,omitempty"`
+	Name             string                 `json:"name"`
+	OldName          string                 `json:"oldName,omitempty"`
+	// Reason explains why the action is skipped.
+	Reason string `json:"reason,omitempty"`
+}
+
+func (a TogglProjectAction) String() string {
+	switch a.Type {
+	case TogglProjectRename:
+		return fmt.Sprintf("rename %s to %s", a.OldName, a.Name)
+	case TogglProjectRestore:
+		if a.OldName != a.Name {
+			return fmt.Sprintf("restore %s as %s", a.OldName, a.Name)
+		}
+		return fmt.Sprintf("restore %s", a.Name)
+	case TogglProjectSkip:
+		return fmt.Sprintf("skip %s, %s", a.Name, a.Reason)
+	default:
+		return fmt.Sprintf("%s %s", a.Type, a.Name)
+	}
+}
+
+type TogglProjectSyncResponse struct {
+	DryRun  bool                 `json:"dryRun"`
+	Actions []TogglProjectAction `json:"actions"`
+}
+
+// SyncTogglProjects creates, renames, archives and restores Toggl projects so that they mirror the Todoist
+// projects selected by config. The mapping between them is kept in store.
+func SyncTogglProjects(todoistApiToken string, config TogglProjectSyncConfig, store backup.Store) (*TogglProjectSyncResponse, error) {
+	if len(config.Areas) == 0 && len(config.Labels) == 0 {
+		return nil, errors.New("no areas or labels select Todoist projects to mirror")
+	}
+
+	ctx := context.Background()
+	workspaceID, err := strconv.ParseInt(config.TogglWorkspaceID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	todoistClient := todoist.NewClient(todoistApiToken)
+	selected, err := selectMirroredProjects(ctx, todoistClient, config)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := loadTogglProjectMapping(store)
+	if err != nil {
+		return nil, err
+	}
+
+	togglClient := toggl.NewToggl(config.TogglApiToken)
+	togglProjects, err := listMappedTogglProjects(ctx, &togglClient, workspaceID, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := planTogglProjectSync(selected, mapping, togglProjects)
+	if config.DryRun {
+		return &TogglProjectSyncResponse{DryRun: true, Actions: actions}, nil
+	}
+
+	// timers started by this process must not resolve names cached before the sync
+	defer togglClient.ForgetCachedNames(workspaceID)
+
+	err = applyTogglProjectSync(ctx, &togglClient, workspaceID, actions, mapping, togglProjects, store)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TogglProjectSyncResponse{Actions: actions}, nil
+}
+
+// selectMirroredProjects returns projects under config.Areas and projects with tasks labeled with config.Labels,
+// in Todoist order.
+func selectMirroredProjects(ctx context.Context, todoistClient *todoist.Client, config TogglProjectSyncConfig) ([]todoist.Project, error) {
+	projects, err := todoistClient.ListProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree := todoist.NewProjectTree(projects)
+
+	labeled := map[string]bool{}
+	if len(config.Labels) > 0 {
+		tasks, err := todoistClient.ListTasks(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			if slices.ContainsFunc(task.Labels, func(label string) bool { return slices.Contains(config.Labels, label) }) {
+				labeled[task.ProjectID] = true
+			}
+		}
+	}
+
+	selected := make([]todoist.Project, 0)
+	for _, project := range projects {
+		if labeled[project.ID] || isUnderArea(tree.ByID[project.ID], config.Areas) {
+			selected = append(selected, project)
+		}
+	}
+	return selected, nil
+}
+
+func isUnderArea(node *todoist.ProjectNode, areas []string) bool {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if slices.Contains(areas, parent.Name) || slices.Contains(areas, parent.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// listMappedTogglProjects lists active and archived workspace projects, adding mapped ones that the listing leaves out.
+func listMappedTogglProjects(ctx context.Context, togglClient *toggl.Toggl, workspaceID int64, mapping *TogglProjectMapping) ([]toggl.Project, error) {
+	projects, err := togglClient.ListAllProjects(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, togglProjectID := range mapping.Projects {
+		if slices.ContainsFunc(projects, func(p toggl.Project) bool { return p.ID == togglProjectID }) {
+			continue
+		}
+
+		project, err := togglClient.GetProject(ctx, workspaceID, togglProjectID)
+		var apiErr *toggl.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, *project)
+	}
+	return projects, nil
+}
+
+// planTogglProjectSync compares the selected Todoist projects with the mapped Toggl projects.
+// Toggl projects missing from mapping are only used to detect name conflicts.
+// Toggl project names are unique in a workspace, so selected projects sharing a name are mirrored once.
+func planTogglProjectSync(selected []todoist.Project, mapping *TogglProjectMapping, togglProjects []toggl.Project) []TogglProjectAction {
+	byID := make(map[int64]toggl.Project, len(togglProjects))
+	for _, project := range togglProjects {
+		byID[project.ID] = project
+	}
+
+	managed := map[int64]bool{}
+	for _, togglProjectID := range mapping.Projects {
+		managed[togglProjectID] = true
+	}
+	isTakenByUnmanaged := func(name string) bool {
+		return slices.ContainsFunc(togglProjects, func(p toggl.Project) bool {
+			return !managed[p.ID] && strings.EqualFold(p.Name, name)
+		})
+	}
+
+	mirrored := map[string]bool{}
+	for _, project := range selected {
+		mirrored[project.ID] = true
+	}
+
+	// owners keeps the Todoist project that a lowercased Toggl project name belongs to, names of managed
+	// projects that are not renamed are claimed first
+	owners := map[string]string{}
+	for todoistProjectID, togglProjectID := range mapping.Projects {
+		togglProject, ok := byID[togglProjectID]
+		if ok && (!mirrored[todoistProjectID] || slices.ContainsFunc(selected, func(p todoist.Project) bool {
+			return p.ID == todoistProjectID && p.Name == togglProject.Name
+		})) {
+			owners[strings.ToLower(togglProject.Name)] = todoistProjectID
+		}
+	}
+
+	actions := make([]TogglProjectAction, 0)
+	for _, project := range selected {
+		action := TogglProjectAction{TodoistProjectID: project.ID, Name: project.Name}
+
+		togglProject, ok := byID[mapping.Projects[project.ID]]
+		switch {
+		case !ok:
+			action.Type = TogglProjectCreate
+		case !togglProject.Active:
+			action.Type = TogglProjectRestore
+		case togglProject.Name != project.Name:
+			action.Type = TogglProjectRename
+		default:
+			continue
+		}
+		if ok {
+			action.TogglProjectID = togglProject.ID
+			action.OldName = togglProject.Name
+		}
+
+		owner, claimed := owners[strings.ToLower(action.Name)]
+		switch {
+		case claimed && owner != project.ID:
+			action.Type = TogglProjectSkip
+			action.Reason = "another Todoist project mirrored by the sync has this name"
+		case !strings.EqualFold(action.OldName, action.Name) && isTakenByUnmanaged(action.Name):
+			action.Type = TogglProjectSkip
+			action.Reason = "a Toggl project with this name is not managed by the sync"
+		default:
+			owners[strings.ToLower(action.Name)] = project.ID
+		}
+		actions = append(actions, action)
+	}
+
+	todoistProjectIDs := make([]string, 0, len(mapping.Projects))
+	for todoistProjectID := range mapping.Projects {
+		todoistProjectIDs = append(todoistProjectIDs, todoistProjectID)
+	}
+	sort.Strings(todoistProjectIDs)
+
+	for _, todoistProjectID := range todoistProjectIDs {
+		togglProject, ok := byID[mapping.Projects[todoistProjectID]]
+		if mirrored[todoistProjectID] || !ok || !togglProject.Active {
+			continue
+		}
+		actions = append(actions, TogglProjectAction{
+			Type:             TogglProjectArchive,
+			TodoistProjectID: todoistProjectID,
+			TogglProjectID:   togglProject.ID,
+			Name:             togglProject.Name,
+		})
+	}
+
+	return actions
+}
+
+// applyTogglProjectSync runs the planned actions and saves the mapping, also when an action fails.
+// IDs of created Toggl projects are set on actions.
+func applyTogglProjectSync(
+	ctx context.Context,
+	togglClient *toggl.Toggl,
+	workspaceID int64,
+	actions []TogglProjectAction,
+	mapping *TogglProjectMapping,
+	togglProjects []toggl.Project,
+	store backup.Store,
+) error {
+	for i, action := range actions {
+		togglProjectID, err := applyTogglProjectAction(ctx, togglClient, workspaceID, action)
+		if err != nil {
+			// keep projects created so far managed, otherwise the next run skips them as unmanaged
+			saveErr := saveTogglProjectMapping(store, mapping)
+			return errors.Join(fmt.Errorf("failed to %s: %w", action, err), saveErr)
+		}
+		if action.Type == TogglProjectCreate {
+			actions[i].TogglProjectID = togglProjectID
+			mapping.Projects[action.TodoistProjectID] = togglProjectID
+		}
+	}
+
+	// drop Toggl projects deleted outside the sync, they are recreated if still mirrored
+	for todoistProjectID, togglProjectID := range mapping.Projects {
+		if !slices.ContainsFunc(togglProjects, func(p toggl.Project) bool { return p.ID == togglProjectID }) &&
+			!slices.ContainsFunc(actions, func(a TogglProjectAction) bool { return a.TogglProjectID == togglProjectID }) {
+			delete(mapping.Projects, todoistProjectID)
+		}
+	}
+
+	return saveTogglProjectMapping(store, mapping)
+}
+
+// applyTogglProjectAction runs action and returns the ID of the created or changed Toggl project.
+func applyTogglProjectAction(ctx context.Context, togglClient *toggl.Toggl, workspaceID int64, action TogglProjectAction) (int64, error) {
+	active := action.Type != TogglProjectArchive
+
+	switch action.Type {
+	case TogglProjectCreate:
+		project, err := togglClient.CreateProject(ctx, workspaceID, toggl.ProjectRequest{Name: action.Name, Active: &active})
+		if err != nil {
+			return 0, err
+		}
+		return project.ID, nil
+	case TogglProjectRename, TogglProjectRestore:
+		_, err := togglClient.UpdateProject(ctx, workspaceID, action.TogglProjectID, toggl.ProjectRequest{Name: action.Name, Active: &active})
+		return action.TogglProjectID, err
+	case TogglProjectArchive:
+		_, err := togglClient.UpdateProject(ctx, workspaceID, action.TogglProjectID, toggl.ProjectRequest{Active: &active})
+		return action.TogglProjectID, err
+	default:
+		return action.TogglProjectID, nil
+	}
+}
+
+func loadTogglProjectMapping(store backup.Store) (*TogglProjectMapping, error) {
+	mapping := &TogglProjectMapping{Projects: map[string]int64{}}
+
+	b, err := store.Load(togglProjectMappingName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return mapping, nil
+	}
+	if err != nil {
+		// stores report a missing file differently, so check the listing before failing
+		names, listErr := store.List()
+		if listErr != nil || slices.Contains(names, togglProjectMappingName) {
+			return nil, err
+		}
+		return mapping, nil
+	}
+
+	err = json.Unmarshal(b, mapping)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", togglProjectMappingName, err)
+	}
+	if mapping.Projects == nil {
+		mapping.Projects = map[string]int64{}
+	}
+	return mapping, nil
+}
+
+func saveTogglProjectMapping(store backup.Store, mapping *TogglProjectMapping) error {
+	b, err := json.MarshalIndent(mapping, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.Save(togglProjectMappingName, b)
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/valeriikundas/todoist-scripts/backup"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+	"github.com/valeriikundas/todoist-scripts/toggl"
+)
+
+func newTestToggl(t *testing.T, handler http.HandlerFunc) *toggl.Toggl {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	togglClient := toggl.NewToggl(t.Name(), toggl.WithBaseURL(server.URL), toggl.WithHTTPClient(server.Client()))
+	return &togglClient
+}
+
+func TestPlanTogglProjectSync(t *testing.T) {
+	const (
+		unmanagedReason = "a Toggl project with this name is not managed by the sync"
+		duplicateReason = "another Todoist project mirrored by the sync has this name"
+	)
+
+	tests := []struct {
+		name          string
+		selected      []todoist.Project
+		mapping       map[string]int64
+		togglProjects []toggl.Project
+		want          []TogglProjectAction
+	}{
+		{
+			name:     "create",
+			selected: []todoist.Project{{ID: "work", Name: "Work"}},
+			want:     []TogglProjectAction{{Type: TogglProjectCreate, TodoistProjectID: "work", Name: "Work"}},
+		},
+		{
+			name:          "rename",
+			selected:      []todoist.Project{{ID: "work", Name: "Work"}},
+			mapping:       map[string]int64{"work": 10},
+			togglProjects: []toggl.Project{{ID: 10, Name: "Job", Active: true}},
+			want:          []TogglProjectAction{{Type: TogglProjectRename, TodoistProjectID: "work", TogglProjectID: 10, Name: "Work", OldName: "Job"}},
+		},
+		{
+			name:          "restore",
+			selected:      []todoist.Project{{ID: "work", Name: "Work"}},
+			mapping:       map[string]int64{"work": 10},
+			togglProjects: []toggl.Project{{ID: 10, Name: "Work"}},
+			want:          []TogglProjectAction{{Type: TogglProjectRestore, TodoistProjectID: "work", TogglProjectID: 10, Name: "Work", OldName: "Work"}},
+		},
+		{
+			name:          "archive",
+			selected:      []todoist.Project{{ID: "home", Name: "Home"}},
+			mapping:       map[string]int64{"home": 11, "work": 10},
+			togglProjects: []toggl.Project{{ID: 10, Name: "Work", Active: true}, {ID: 11, Name: "Home", Active: true}},
+			want:          []TogglProjectAction{{Type: TogglProjectArchive, TodoistProjectID: "work", TogglProjectID: 10, Name: "Work"}},
+		},
+		{
+			name:          "skip a name taken by an unmanaged project",
+			selected:      []todoist.Project{{ID: "work", Name: "Work"}},
+			togglProjects: []toggl.Project{{ID: 20, Name: "work", Active: true}},
+			want:          []TogglProjectAction{{Type: TogglProjectSkip, TodoistProjectID: "work", Name: "Work", Reason: unmanagedReason}},
+		},
+		{
+			name:     "skip a duplicate mirrored name",
+			selected: []todoist.Project{{ID: "work", Name: "Work"}, {ID: "client-work", Name: "Work"}},
+			want: []TogglProjectAction{
+				{Type: TogglProjectCreate, TodoistProjectID: "work", Name: "Work"},
+				{Type: TogglProjectSkip, TodoistProjectID: "client-work", Name: "Work", Reason: duplicateReason},
+			},
+		},
+		{
+			name:          "unchanged projects claim their names first",
+			selected:      []todoist.Project{{ID: "home", Name: "Work"}, {ID: "work", Name: "Work"}},
+			mapping:       map[string]int64{"home": 11, "work": 10},
+			togglProjects: []toggl.Project{{ID: 10, Name: "Work", Active: true}, {ID: 11, Name: "Home", Active: true}},
+			want:          []TogglProjectAction{{Type: TogglProjectSkip, TodoistProjectID: "home", TogglProjectID: 11, Name: "Work", OldName: "Home", Reason: duplicateReason}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := &TogglProjectMapping{Projects: map[string]int64{}}
+			for todoistProjectID, togglProjectID := range tt.mapping {
+				mapping.Projects[todoistProjectID] = togglProjectID
+			}
+
+			got := planTogglProjectSync(tt.selected, mapping, tt.togglProjects)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("actions = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncNeverTouchesUnmanagedTogglProjects(t *testing.T) {
+	unmanaged := []toggl.Project{
+		{ID: 20, Name: "Personal", Active: true},
+		{ID: 21, Name: "Errands"},
+	}
+	togglProjects := append([]toggl.Project{{ID: 10, Name: "Old", Active: true}}, unmanaged...)
+	selected := []todoist.Project{{ID: "personal", Name: "Personal"}, {ID: "errands", Name: "Errands"}, {ID: "misc", Name: "Misc"}}
+	mapping := &TogglProjectMapping{Projects: map[string]int64{"personal": 10}}
+
+	togglClient := newTestToggl(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v9/workspaces/7/projects" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"id": 30, "name": "Misc", "active": true}`)
+	})
+
+	actions := planTogglProjectSync(selected, mapping, togglProjects)
+	for _, action := range actions {
+		if slices.ContainsFunc(unmanaged, func(p toggl.Project) bool { return p.ID == action.TogglProjectID }) {
+			t.Errorf("planned %s on an unmanaged project", action)
+		}
+	}
+
+	err := applyTogglProjectSync(context.Background(), togglClient, 7, actions, mapping, togglProjects, backup.NewLocalStore(t.TempDir()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mapping.Projects) != 2 || mapping.Projects["misc"] != 30 || mapping.Projects["personal"] != 10 {
+		t.Errorf("mapping = %v, want personal and misc only", mapping.Projects)
+	}
+}
+
+func TestApplyTogglProjectSyncSavesMappingOnFailure(t *testing.T) {
+	togglClient := newTestToggl(t, func(w http.ResponseWriter, r *http.Request) {
+		var request toggl.ProjectRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			t.Error(err)
+		}
+		if request.Name == "Home" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `{"id": 30, "name": %q, "active": true}`, request.Name)
+	})
+	store := backup.NewLocalStore(t.TempDir())
+	mapping := &TogglProjectMapping{Projects: map[string]int64{}}
+	actions := []TogglProjectAction{
+		{Type: TogglProjectCreate, TodoistProjectID: "work", Name: "Work"},
+		{Type: TogglProjectCreate, TodoistProjectID: "home", Name: "Home"},
+	}
+
+	err := applyTogglProjectSync(context.Background(), togglClient, 7, actions, mapping, nil, store)
+	if err == nil || !strings.Contains(err.Error(), "failed to create Home") {
+		t.Fatalf("err = %v, want the failed action", err)
+	}
+
+	saved, err := loadTogglProjectMapping(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Projects) != 1 || saved.Projects["work"] != 30 {
+		t.Errorf("saved mapping = %v, want the project created before the failure", saved.Projects)
+	}
+}
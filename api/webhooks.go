@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+)
+
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// webhookDeliveries lives as long as the process, so duplicates are caught within a warm Lambda or Encore instance.
+var webhookDeliveries = todoist.NewDeliveryDeduplicator(24 * time.Hour)
+
+type WebhookConfig struct {
+	TodoistApiToken     string
+	TodoistClientSecret string
+	TelegramApiToken    string
+	TelegramUserID      string
+	CheckConfig         todoist.NextActionCheckConfig
+}
+
+type WebhookResponse struct {
+	EventName string                           `json:"eventName"`
+	Duplicate bool                             `json:"duplicate"`
+	Warnings  []todoist.IncorrectProjectSchema `json:"warnings,omitempty"`
+}
+
+// HandleTodoistWebhook verifies and dispatches a Todoist webhook delivery.
+// signature and deliveryID are the `X-Todoist-Hmac-SHA256` and `X-Todoist-Delivery-ID` headers.
+func HandleTodoistWebhook(ctx context.Context, config WebhookConfig, signature, deliveryID string, body []byte) (*WebhookResponse, error) {
+	if !todoist.VerifyWebhookSignature(body, signature, config.TodoistClientSecret) {
+		return nil, ErrInvalidWebhookSignature
+	}
+
+	event, err := todoist.ParseWebhookEvent(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// the delivery is marked before dispatch so that concurrent retries are skipped, and forgotten if it fails
+	response := &WebhookResponse{EventName: event.EventName}
+	if deliveryID != "" && webhookDeliveries.Seen(deliveryID, time.Now()) {
+		log.Printf("skipping duplicate webhook delivery %s", deliveryID)
+		response.Duplicate = true
+		return response, nil
+	}
+
+	checkNextActionLimit := func(ctx context.Context, task todoist.Task) error {
+		warnings, err := warnAboutNextActionLimit(config, task)
+		response.Warnings = warnings
+		return err
+	}
+	handlers := todoist.WebhookHandlers{
+		OnItemAdded: func(ctx context.Context, task todoist.Task) error {
+			if !task.IsNextAction() {
+				return nil
+			}
+			return checkNextActionLimit(ctx, task)
+		},
+		OnItemUpdated: func(ctx context.Context, task todoist.Task, oldTask *todoist.Task) error {
+			if !task.IsNextAction() {
+				return nil
+			}
+			// only label and project changes can push a project over its limit
+			if oldTask != nil && oldTask.IsNextAction() && oldTask.ProjectID == task.ProjectID && oldTask.SectionID == task.SectionID {
+				return nil
+			}
+			return checkNextActionLimit(ctx, task)
+		},
+	}
+
+	err = handlers.Dispatch(ctx, *event)
+	if err != nil {
+		if deliveryID != "" {
+			webhookDeliveries.Forget(deliveryID)
+		}
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// warnAboutNextActionLimit sends a Telegram message if the task's project is over its @next_action limit.
+func warnAboutNextActionLimit(config WebhookConfig, task todoist.Task) ([]todoist.IncorrectProjectSchema, error) {
+	todoistClient := todoist.NewClient(config.TodoistApiToken)
+	violations := todoistClient.GetNextActionLimitViolations(task.ProjectID, config.CheckConfig)
+	if len(violations) == 0 {
+		return nil, nil
+	}
+
+	telegramUserID, err := strconv.Atoi(config.TelegramUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf(
+		"*%s* went over the @next_action limit\n\n%s",
+		telegram.EscapeText(task.Content),
+		todoistClient.PrettyOutput(violations, nil),
+	)
+	tg := telegram.NewTelegram(config.TelegramApiToken)
+	err = tg.Send(telegramUserID, message, telegram.ParseModeMarkdownV2)
+	if err != nil {
+		return nil, err
+	}
+
+	return violations, nil
+}
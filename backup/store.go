@@ -0,0 +1,135 @@
+package backup
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+)
+
+// Store saves and loads named snapshot files.
+type Store interface {
+	Save(name string, b []byte) error
+	Load(name string) ([]byte, error)
+	// List returns snapshot names sorted in ascending order.
+	List() ([]string, error)
+}
+
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+func (s *LocalStore) Save(name string, b []byte) error {
+	err := os.MkdirAll(s.dir, 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, name), b, 0o644)
+}
+
+func (s *LocalStore) Load(name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.dir, name))
+}
+
+func (s *LocalStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// S3Store keeps snapshots in an S3 bucket or any S3-compatible storage.
+type S3Store struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+type S3StoreConfig struct {
+	Bucket string
+	Prefix string
+	Region string
+	// Endpoint is set for S3-compatible storage such as MinIO or R2, and enables path-style addressing.
+	Endpoint string
+}
+
+func NewS3Store(config S3StoreConfig) (*S3Store, error) {
+	awsConfig := aws.NewConfig().WithRegion(config.Region)
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(config.Endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create aws session")
+	}
+
+	return &S3Store{
+		client: s3.New(sess),
+		bucket: config.Bucket,
+		prefix: config.Prefix,
+	}, nil
+}
+
+func (s *S3Store) Save(name string, b []byte) error {
+	_, err := s.client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(path.Join(s.prefix, name)),
+		Body:        bytes.NewReader(b),
+		ContentType: aws.String("application/json"),
+	})
+	return errors.Wrapf(err, "failed to save %s", name)
+}
+
+func (s *S3Store) Load(name string) ([]byte, error) {
+	out, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path.Join(s.prefix, name)),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load %s", name)
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
+
+func (s *S3Store) List() ([]string, error) {
+	names := make([]string, 0)
+	err := s.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			names = append(names, path.Base(aws.StringValue(object.Key)))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list snapshots")
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
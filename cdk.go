@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awslambda"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awslogs"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awssecretsmanager"
 	"github.com/aws/aws-cdk-go/awscdklambdagoalpha/v2"
 	"github.com/aws/constructs-go/constructs/v10"
@@ -64,8 +65,60 @@ func NewCdkStack(scope constructs.Construct, id string, props *CdkStackProps) aw
 			Entry:         jsii.String("lambdas/archive-older-inbox-tasks/main.go"),
 			Runtime:       awslambda.Runtime_GO_1_X(),
 			InitialPolicy: &[]awsiam.PolicyStatement{readSecretsPolicyStatement},
+			Environment:   utils.ReadConfig(),
+		},
+	)
+	inboxReminder := awscdklambdagoalpha.NewGoFunction(
+		stack,
+		jsii.String("inbox-reminder"),
+		&awscdklambdagoalpha.GoFunctionProps{
+			LogRetention:  awslogs.RetentionDays_THREE_DAYS,
+			Timeout:       awscdk.Duration_Seconds(jsii.Number(30)),
+			Entry:         jsii.String("lambdas/inbox-reminder/main.go"),
+			Runtime:       awslambda.Runtime_GO_1_X(),
+			InitialPolicy: &[]awsiam.PolicyStatement{readSecretsPolicyStatement},
+			Environment:   utils.ReadConfig(),
+		},
+	)
+	backupBucket := awss3.NewBucket(stack, jsii.String("todoist-backups"), &awss3.BucketProps{
+		Versioned:         jsii.Bool(true),
+		BlockPublicAccess: awss3.BlockPublicAccess_BLOCK_ALL(),
+		RemovalPolicy:     awscdk.RemovalPolicy_RETAIN,
+	})
+	backupTodoist := awscdklambdagoalpha.NewGoFunction(
+		stack,
+		jsii.String("backup-todoist"),
+		&awscdklambdagoalpha.GoFunctionProps{
+			LogRetention:  awslogs.RetentionDays_THREE_DAYS,
+			Timeout:       awscdk.Duration_Seconds(jsii.Number(60)),
+			Entry:         jsii.String("lambdas/backup-todoist/main.go"),
+			Runtime:       awslambda.Runtime_GO_1_X(),
+			InitialPolicy: &[]awsiam.PolicyStatement{readSecretsPolicyStatement},
+			Environment: &map[string]*string{
+				"BackupBucket": backupBucket.BucketName(),
+			},
 		},
 	)
+	backupBucket.GrantReadWrite(backupTodoist, nil)
+	todoistWebhook := awscdklambdagoalpha.NewGoFunction(
+		stack,
+		jsii.String("todoist-webhook"),
+		&awscdklambdagoalpha.GoFunctionProps{
+			LogRetention:  awslogs.RetentionDays_THREE_DAYS,
+			Timeout:       awscdk.Duration_Seconds(jsii.Number(30)),
+			Entry:         jsii.String("lambdas/todoist-webhook/main.go"),
+			Runtime:       awslambda.Runtime_GO_1_X(),
+			InitialPolicy: &[]awsiam.PolicyStatement{readSecretsPolicyStatement},
+			Environment:   utils.ReadConfig(),
+		},
+	)
+	// the webhook verifies Todoist's HMAC signature itself
+	todoistWebhookUrl := todoistWebhook.AddFunctionUrl(&awslambda.FunctionUrlOptions{
+		AuthType: awslambda.FunctionUrlAuthType_NONE,
+	})
+	awscdk.NewCfnOutput(stack, jsii.String("todoist-webhook-url"), &awscdk.CfnOutputProps{
+		Value: todoistWebhookUrl.Url(),
+	})
 
 	// scheduling
 	scheduleDaily8AM := awsevents.Schedule_Cron(&awsevents.CronOptions{
@@ -96,6 +149,29 @@ func NewCdkStack(scope constructs.Construct, id string, props *CdkStackProps) aw
 		},
 	})
 
+	awsevents.NewRule(stack, jsii.String("backup-todoist-daily"), &awsevents.RuleProps{
+		Schedule: scheduleDaily8AM,
+		Targets: &[]awsevents.IRuleTarget{
+			awseventstargets.NewLambdaFunction(
+				backupTodoist,
+				&awseventstargets.LambdaFunctionProps{},
+			),
+		},
+	})
+
+	scheduleHourly := awsevents.Schedule_Cron(&awsevents.CronOptions{
+		Minute: jsii.String("0"),
+	})
+	awsevents.NewRule(stack, jsii.String("inbox-reminder-hourly"), &awsevents.RuleProps{
+		Schedule: scheduleHourly,
+		Targets: &[]awsevents.IRuleTarget{
+			awseventstargets.NewLambdaFunction(
+				inboxReminder,
+				&awseventstargets.LambdaFunctionProps{},
+			),
+		},
+	})
+
 	return stack
 }
 
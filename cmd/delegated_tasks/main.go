@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/valeriikundas/todoist-scripts/api"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	sendToTelegram := flag.Bool("telegram", false, "send a digest per person to Telegram instead of printing")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("error loading .env file, %v", err)
+	}
+
+	todoistApiToken := os.Getenv("TODOIST_API_TOKEN")
+
+	if *sendToTelegram {
+		_, err := api.SendDelegationDigestToTelegram(todoistApiToken, os.Getenv("TELEGRAM_API_TOKEN"), os.Getenv("TELEGRAM_USER_ID"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	resp, err := api.GetDelegatedTasks(todoistApiToken)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, group := range resp.Delegated {
+		fmt.Printf("%s:\n", group.Person)
+		printRows(group.Tasks)
+	}
+	if len(resp.AssignedToMe) > 0 {
+		fmt.Println("assigned to me:")
+		printRows(resp.AssignedToMe)
+	}
+}
+
+func printRows(rows []todoist.AssignedTaskSchema) {
+	for _, row := range rows {
+		fmt.Printf("    %3dd  %s  (%s)\n", row.AgeDays, row.Content, row.Path)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	fileName := flag.String("file", "", "file to import")
+	format := flag.String("format", "", "markdown, csv or text. Detected from the file extension by default")
+	projectName := flag.String("project", "Inbox", "project for tasks without a #project token")
+	dryRun := flag.Bool("dry-run", false, "print the tasks and commands without creating them")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("error loading .env file, %v", err)
+	}
+
+	todoistApiToken := os.Getenv("TODOIST_API_TOKEN")
+
+	if *format == "" {
+		*format = formatFromExtension(*fileName)
+	}
+
+	file, err := os.Open(*fileName)
+	if err != nil {
+		log.Fatalf("error opening %s, %v", *fileName, err)
+	}
+	defer file.Close()
+
+	tasks, err := todoist.ParseImport(file, todoist.ImportFormat(*format))
+	if err != nil {
+		log.Fatalf("error parsing %s, %v", *fileName, err)
+	}
+
+	fmt.Print(todoist.PrettyImportPreview(tasks, *projectName))
+
+	todoistClient := todoist.NewClient(todoistApiToken)
+	result, err := todoistClient.ImportTasks(tasks, *projectName, *dryRun)
+	if err != nil {
+		log.Fatalf("error importing tasks, %v", err)
+	}
+
+	if *dryRun {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(result.Commands)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	log.Printf("imported %d tasks with %d commands", len(tasks), len(result.Commands))
+}
+
+func formatFromExtension(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".md", ".markdown":
+		return string(todoist.ImportFormatMarkdown)
+	case ".csv":
+		return string(todoist.ImportFormatCSV)
+	default:
+		return string(todoist.ImportFormatText)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/valeriikundas/todoist-scripts/api"
+)
+
+type varsFlag map[string]string
+
+func (v varsFlag) String() string {
+	return ""
+}
+
+func (v varsFlag) Set(s string) error {
+	key, value, _ := strings.Cut(s, "=")
+	v[key] = value
+	return nil
+}
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	vars := varsFlag{}
+	templatesDir := flag.String("dir", "./templates", "directory with project templates")
+	name := flag.String("template", "", "template name, without the .yaml extension")
+	dryRun := flag.Bool("dry-run", false, "print commands without sending them")
+	flag.Var(vars, "var", "template variable as Key=value, can be repeated. Date=YYYY-MM-DD sets the base date for due dates")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("error loading .env file, %v", err)
+	}
+
+	todoistApiToken := os.Getenv("TODOIST_API_TOKEN")
+
+	result, err := api.InstantiateProjectTemplate(todoistApiToken, *templatesDir, *name, vars, *dryRun)
+	if err != nil {
+		log.Fatalf("error instantiating template, %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(result)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
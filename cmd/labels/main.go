@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+)
+
+const usage = `usage:
+  labels list
+  labels [-dry-run] ensure [name:color ...]
+  labels [-dry-run] rename <old> <new>
+  labels [-dry-run] merge <from> <into>
+  labels [-dry-run] delete-unused
+
+ensure creates the GTD labels (next_action, someday_maybe, waiting_for, reference) unless labels are given.
+delete-unused keeps the GTD labels.`
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	dryRun := flag.Bool("dry-run", false, "print changes without applying them")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("error loading .env file, %v", err)
+	}
+
+	todoistClient := todoist.NewClient(os.Getenv("TODOIST_API_TOKEN"))
+
+	var changes *todoist.LabelChanges
+	switch flag.Arg(0) {
+	case "list":
+		fmt.Print(todoist.PrettyLabelUsageOutput(todoistClient.GetLabelUsage()))
+		return
+	case "ensure":
+		required := todoist.GTDLabels
+		if flag.NArg() > 1 {
+			required = parseRequiredLabels(flag.Args()[1:])
+		}
+		changes, err = todoistClient.EnsureLabels(required, *dryRun)
+	case "rename":
+		requireArgs(3)
+		changes, err = todoistClient.RenameLabel(flag.Arg(1), flag.Arg(2), *dryRun)
+	case "merge":
+		requireArgs(3)
+		changes, err = todoistClient.MergeLabels(flag.Arg(1), flag.Arg(2), *dryRun)
+	case "delete-unused":
+		keep := make([]string, 0, len(todoist.GTDLabels))
+		for _, l := range todoist.GTDLabels {
+			keep = append(keep, l.Name)
+		}
+		changes, err = todoistClient.DeleteUnusedLabels(keep, *dryRun)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("error applying label changes, %v", err)
+	}
+
+	log.Printf("%d changes", len(changes.Changes))
+}
+
+func requireArgs(n int) {
+	if flag.NArg() != n {
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func parseRequiredLabels(args []string) []todoist.RequiredLabel {
+	labels := make([]todoist.RequiredLabel, 0, len(args))
+	for _, arg := range args {
+		name, color, _ := strings.Cut(arg, ":")
+		labels = append(labels, todoist.RequiredLabel{Name: strings.TrimPrefix(name, "@"), Color: color})
+	}
+	return labels
+}
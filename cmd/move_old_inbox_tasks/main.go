@@ -1,9 +1,9 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"os"
-	"time"
 
 	"github.com/joho/godotenv"
 	todoist "github.com/valeriikundas/todoist-scripts/todoist"
@@ -12,7 +12,15 @@ import (
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	err := godotenv.Load()
+	protect := flag.String("protect", todoist.DefaultProtectedPriority.String(), "never move tasks with this priority or higher, p1..p4")
+	flag.Parse()
+
+	protectedPriority, err := todoist.ParsePriority(*protect)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = godotenv.Load()
 	if err != nil {
 		log.Fatalf("error loading .env file, %v", err)
 	}
@@ -21,9 +29,9 @@ func main() {
 
 	srcProjectName := "Inbox"
 	dstProjectName := "inbox_archive"
-	oldThreshold := time.Hour * 24 * 3
+	olderThanDays := 3
 	dryRun := false
 
 	todoistClient := todoist.NewClient(todoistApiToken)
-	todoistClient.MoveInactiveTasks(srcProjectName, dstProjectName, oldThreshold, dryRun)
+	todoistClient.MoveInactiveTasks(srcProjectName, dstProjectName, olderThanDays, protectedPriority, dryRun)
 }
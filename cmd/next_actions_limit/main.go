@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"github.com/joho/godotenv"
 	"github.com/valeriikundas/todoist-scripts/telegram"
 	"github.com/valeriikundas/todoist-scripts/todoist"
@@ -19,6 +20,14 @@ func must(err error) {
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
+	enforce := flag.String("enforce", "", "demote excess @next_action tasks, keeping the first by this ordering, e.g. priority,due,age")
+	demoteLabel := flag.String("demote-label", "someday_maybe", "label that replaces @next_action on demoted tasks")
+	promote := flag.String("promote", "", "add @next_action to projects without one, picking the first task by `order` or `priority`")
+	lint := flag.String("lint", "", "report badly worded tasks, a comma separated list of rules or `all`")
+	lintLanguages := flag.String("lint-languages", "en", "comma separated languages of the verb list used by the lint")
+	dryRun := flag.Bool("dry-run", false, "only report which tasks would be demoted or promoted")
+	flag.Parse()
+
 	err := godotenv.Load()
 	if err != nil {
 		log.Fatalf("error loading .env file, %v", err)
@@ -40,18 +49,93 @@ func main() {
 	decoder := json.NewDecoder(file)
 	var config struct {
 		ExcludeFromZeroProjectsList []string
+		NextActionProjectLimits     map[string]int
+		NextActionSectionLimits     map[string]int
+		CheckZeroPerSection         bool
+		NextActionGlobalLimit       int
+		NextActionAreaLimits        map[string]int
+		ProjectLint                 *todoist.ProjectLintConfig
 	}
 	err = decoder.Decode(&config)
 	must(err)
 
-	projectsWithTooManyTasks, projectsWithZeroTasks := todoistClient.GetProjectsWithTooManyAndZeroTasks(nextActionsTasksLimitPerProject, config.ExcludeFromZeroProjectsList)
+	checkConfig := todoist.NextActionCheckConfig{
+		Limit:                       nextActionsTasksLimitPerProject,
+		ProjectLimits:               config.NextActionProjectLimits,
+		ExcludeFromZeroProjectsList: config.ExcludeFromZeroProjectsList,
+		SectionLimits:               config.NextActionSectionLimits,
+		CheckZeroPerSection:         config.CheckZeroPerSection,
+		GlobalLimit:                 config.NextActionGlobalLimit,
+		AreaLimits:                  config.NextActionAreaLimits,
+	}
+
+	demotionMessage := ""
+	if *enforce != "" {
+		enforceConfig, err := todoist.ParseWIPEnforcementConfig(*enforce, *demoteLabel)
+		must(err)
+		enforceConfig.DryRun = *dryRun
+
+		demoted, err := todoistClient.EnforceNextActionLimits(checkConfig, *enforceConfig)
+		must(err)
+		log.Printf("demoted=%+v", demoted)
+		demotionMessage = todoistClient.PrettyDemotionOutput(demoted, enforceConfig.DemoteLabel)
+	}
+
+	promotionMessage := ""
+	if *promote != "" {
+		promoteConfig, err := todoist.ParseNextActionPromotionConfig(*promote)
+		must(err)
+		promoteConfig.DryRun = *dryRun
+
+		promoted, err := todoistClient.PromoteNextActions(checkConfig, *promoteConfig)
+		must(err)
+		log.Printf("promoted=%+v", promoted)
+		promotionMessage = todoistClient.PrettyPromotionOutput(promoted)
+	}
+
+	if *dryRun {
+		log.Print(demotionMessage + promotionMessage)
+		return
+	}
+
+	projectsWithTooManyTasks, projectsWithZeroTasks := todoistClient.GetProjectsWithTooManyAndZeroTasks(checkConfig)
 	log.Printf("projectsWithTooManyTasks=%+v projectsWithZeroTasks=%+v", projectsWithTooManyTasks, projectsWithZeroTasks)
 
-	message := todoistClient.PrettyOutput(projectsWithTooManyTasks, projectsWithZeroTasks)
+	message := demotionMessage + promotionMessage + todoistClient.PrettyOutput(projectsWithTooManyTasks, projectsWithZeroTasks)
+
+	lintConfig, err := todoist.ParseTaskLintConfig(*lint, *lintLanguages, "")
+	must(err)
+	lintMessage := ""
+	if lintConfig != nil {
+		lintRows := todoistClient.LintTasks(*lintConfig)
+		log.Printf("lint=%+v", lintRows)
+		lintMessage = todoistClient.PrettyLintOutput(lintRows)
+	}
+
+	projectLintMessage := ""
+	if config.ProjectLint != nil {
+		projectLintRows := todoistClient.LintProjects(*config.ProjectLint)
+		log.Printf("projectLint=%+v", projectLintRows)
+		projectLintMessage = todoistClient.PrettyProjectLintOutput(projectLintRows)
+	}
 
 	tg := telegram.NewTelegram(telegramApiToken)
 	err = tg.Send(chatID, message, telegram.ParseModeMarkdownV2)
 	if err != nil {
 		log.Fatalf("error sending message, %v", err)
 	}
+
+	if lintMessage != "" {
+		err = tg.Send(chatID, lintMessage, telegram.ParseModeMarkdownV2)
+		if err != nil {
+			log.Fatalf("error sending lint message, %v", err)
+		}
+	}
+
+	if projectLintMessage != "" {
+		err = tg.Send(chatID, projectLintMessage, telegram.ParseModeMarkdownV2)
+		if err != nil {
+			log.Fatalf("error sending project lint message, %v", err)
+		}
+	}
 }
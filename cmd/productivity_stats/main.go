@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	days := flag.Int("days", 30, "number of days to compute stats for")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("error loading .env file, %v", err)
+	}
+
+	todoistApiToken := os.Getenv("TODOIST_API_TOKEN")
+
+	todoistClient := todoist.NewClient(todoistApiToken)
+	stats := todoistClient.GetProductivityStats(todoistClient.DaysAgo(*days))
+
+	w := csv.NewWriter(os.Stdout)
+	writeRow := func(metric, key, value string) {
+		err := w.Write([]string{metric, key, value})
+		if err != nil {
+			log.Fatalf("error writing csv, %v", err)
+		}
+	}
+
+	writeRow("metric", "key", "value")
+	writeRow("completed", "", strconv.Itoa(stats.CompletedCount))
+	writeRow("overdue", "", strconv.Itoa(stats.OverdueCount))
+	writeRow("average_lead_time_hours", "", strconv.FormatFloat(stats.AverageLeadTimeHours, 'f', 2, 64))
+	writeCounts(writeRow, "completions_per_day", stats.CompletionsPerDay)
+	writeCounts(writeRow, "completions_per_week", stats.CompletionsPerWeek)
+	writeCounts(writeRow, "completions_per_project", stats.CompletionsPerProject)
+	writeCounts(writeRow, "completions_per_label", stats.CompletionsPerLabel)
+	writeRow("inbox", "added", strconv.Itoa(stats.Inbox.Added))
+	writeRow("inbox", "completed", strconv.Itoa(stats.Inbox.Completed))
+	writeRow("inbox", "open", strconv.Itoa(stats.Inbox.Open))
+	for _, bucket := range stats.OpenTaskAges {
+		writeRow("open_task_age", bucket.Label, strconv.Itoa(bucket.Count))
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Fatalf("error writing csv, %v", err)
+	}
+}
+
+func writeCounts(writeRow func(metric, key, value string), metric string, counts map[string]int) {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		writeRow(metric, k, strconv.Itoa(counts[k]))
+	}
+}
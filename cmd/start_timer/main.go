@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/valeriikundas/todoist-scripts/api"
+	"github.com/valeriikundas/todoist-scripts/toggl"
+	"github.com/valeriikundas/todoist-scripts/utils"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	taskID := flag.String("task", "", "Todoist task ID to start a timer for")
+	filter := flag.String("filter", "", "Todoist filter query, the timer is started for the first matching task")
+	propose := flag.Bool("telegram", false, "send @next_action tasks as Telegram buttons instead of starting a timer")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("error loading .env file, %v", err)
+	}
+
+	todoistApiToken := os.Getenv("TODOIST_API_TOKEN")
+
+	projectMap, err := utils.ParseNameMap(os.Getenv("TOGGL_PROJECT_MAP"))
+	if err != nil {
+		log.Fatalf("error parsing TOGGL_PROJECT_MAP, %v", err)
+	}
+
+	config := api.TimerConfig{
+		TogglApiToken:    os.Getenv("TOGGL_API_TOKEN"),
+		TogglWorkspaceID: os.Getenv("TOGGL_WORKSPACE_ID"),
+		ProjectMap:       projectMap,
+		StartOptions:     toggl.StartOptions{AutoCreate: os.Getenv("TOGGL_AUTO_CREATE") == "true"},
+	}
+
+	var result any
+	switch {
+	case *propose:
+		result, err = api.ProposeTimerForNextActions(todoistApiToken, os.Getenv("TELEGRAM_API_TOKEN"), os.Getenv("TELEGRAM_USER_ID"))
+	case *taskID != "":
+		result, err = api.StartTimerForTask(todoistApiToken, config, *taskID)
+	case *filter != "":
+		result, err = api.StartTimerForFilter(todoistApiToken, config, *filter)
+	default:
+		log.Fatal("one of -task, -filter or -telegram is required")
+	}
+	if err != nil {
+		log.Fatalf("error starting timer, %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(result)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/valeriikundas/todoist-scripts/api"
+	"github.com/valeriikundas/todoist-scripts/backup"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	areas := flag.String("areas", "", "comma separated parent Todoist projects, by name or path, whose subprojects are mirrored")
+	labels := flag.String("labels", "", "comma separated labels, Todoist projects with tasks carrying them are mirrored")
+	dryRun := flag.Bool("dry-run", false, "list planned creates, renames and archives without changing Toggl")
+	dir := flag.String("dir", "./toggl_sync", "local directory to keep the Todoist to Toggl project mapping in")
+	bucket := flag.String("s3-bucket", "", "keep the mapping in this S3 bucket instead of a local directory")
+	prefix := flag.String("s3-prefix", "toggl", "key prefix for the mapping in the S3 bucket")
+	region := flag.String("s3-region", "eu-central-1", "S3 region")
+	endpoint := flag.String("s3-endpoint", "", "endpoint of S3-compatible storage")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("error loading .env file, %v", err)
+	}
+
+	var store backup.Store = backup.NewLocalStore(*dir)
+	if *bucket != "" {
+		store, err = backup.NewS3Store(backup.S3StoreConfig{
+			Bucket:   *bucket,
+			Prefix:   *prefix,
+			Region:   *region,
+			Endpoint: *endpoint,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	config := api.ParseTogglProjectSyncConfig(
+		os.Getenv("TOGGL_API_TOKEN"),
+		os.Getenv("TOGGL_WORKSPACE_ID"),
+		*areas,
+		*labels,
+		*dryRun,
+	)
+
+	resp, err := api.SyncTogglProjects(os.Getenv("TODOIST_API_TOKEN"), config, store)
+	if err != nil {
+		log.Fatalf("error syncing toggl projects, %v", err)
+	}
+
+	if len(resp.Actions) == 0 {
+		log.Print("toggl projects are in sync")
+	}
+	for _, action := range resp.Actions {
+		if resp.DryRun {
+			log.Printf("would %s", action)
+		} else {
+			log.Print(action)
+		}
+	}
+}
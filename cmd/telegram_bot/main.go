@@ -0,0 +1,127 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/valeriikundas/todoist-scripts/api"
+	"github.com/valeriikundas/todoist-scripts/backup"
+	"github.com/valeriikundas/todoist-scripts/telegram"
+	"github.com/valeriikundas/todoist-scripts/toggl"
+	"github.com/valeriikundas/todoist-scripts/utils"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("error loading .env file, %v", err)
+	}
+
+	telegramApiToken := os.Getenv("TELEGRAM_API_TOKEN")
+	chatID, err := strconv.Atoi(os.Getenv("TELEGRAM_USER_ID"))
+	if err != nil {
+		log.Fatalf("error converting chatID to int, %v", err)
+	}
+
+	templatesDir, ok := os.LookupEnv("TEMPLATES_DIR")
+	if !ok {
+		templatesDir = "./templates"
+	}
+
+	projectMap, err := utils.ParseNameMap(os.Getenv("TOGGL_PROJECT_MAP"))
+	if err != nil {
+		log.Fatalf("error parsing TOGGL_PROJECT_MAP, %v", err)
+	}
+
+	// kept dormant projects must be stored where the proposals read them, i.e. in the backup bucket when they run on encore
+	var store backup.Store = backup.NewLocalStore("./dormant")
+	if bucket := os.Getenv("BACKUP_S3_BUCKET"); bucket != "" {
+		store, err = backup.NewS3Store(backup.S3StoreConfig{
+			Bucket:   bucket,
+			Prefix:   "dormant",
+			Region:   os.Getenv("BACKUP_S3_REGION"),
+			Endpoint: os.Getenv("BACKUP_S3_ENDPOINT"),
+		})
+		if err != nil {
+			log.Fatalf("error creating store, %v", err)
+		}
+	}
+
+	config := api.BotConfig{
+		TodoistApiToken:  os.Getenv("TODOIST_API_TOKEN"),
+		TemplatesDir:     templatesDir,
+		TelegramApiToken: telegramApiToken,
+		TelegramUserID:   os.Getenv("TELEGRAM_USER_ID"),
+		Timer: api.TimerConfig{
+			TogglApiToken:    os.Getenv("TOGGL_API_TOKEN"),
+			TogglWorkspaceID: os.Getenv("TOGGL_WORKSPACE_ID"),
+			ProjectMap:       projectMap,
+			StartOptions:     toggl.StartOptions{AutoCreate: os.Getenv("TOGGL_AUTO_CREATE") == "true"},
+		},
+		Store: store,
+	}
+
+	tg := telegram.NewTelegram(telegramApiToken)
+	offset := 0
+	for {
+		updates, err := tg.GetUpdates(offset, 60)
+		if err != nil {
+			log.Printf("error getting updates, %v", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.ID + 1
+
+			if update.CallbackQuery != nil {
+				handleCallback(tg, config, chatID, *update.CallbackQuery)
+				continue
+			}
+
+			if update.Message.Chat.ID != chatID {
+				log.Printf("ignoring message from unauthorised chat %d", update.Message.Chat.ID)
+				continue
+			}
+
+			reply, err := api.HandleTelegramMessage(config, update.Message)
+			if err != nil {
+				log.Printf("error handling message `%s`, %v", update.Message.Text, err)
+				reply = telegram.EscapeText("failed: " + err.Error())
+			}
+
+			err = tg.Send(chatID, reply, telegram.ParseModeMarkdownV2)
+			if err != nil {
+				log.Printf("error sending reply, %v", err)
+			}
+		}
+	}
+}
+
+func handleCallback(tg telegram.Telegram, config api.BotConfig, chatID int, callback telegram.CallbackQuery) {
+	if callback.Message == nil || callback.Message.Chat.ID != chatID {
+		log.Printf("ignoring callback `%s` from unauthorised chat", callback.Data)
+		return
+	}
+
+	reply, err := api.HandleTelegramCallback(config, callback)
+	if err != nil {
+		log.Printf("error handling callback `%s`, %v", callback.Data, err)
+		reply = "failed: " + err.Error()
+	}
+
+	err = tg.AnswerCallbackQuery(callback.ID, reply)
+	if err != nil {
+		log.Printf("error answering callback, %v", err)
+	}
+
+	err = tg.Send(chatID, reply, telegram.ParseModeNone)
+	if err != nil {
+		log.Printf("error sending reply, %v", err)
+	}
+}
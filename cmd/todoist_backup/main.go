@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/valeriikundas/todoist-scripts/api"
+	"github.com/valeriikundas/todoist-scripts/backup"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+)
+
+const usage = `usage:
+  todoist_backup [flags] save
+  todoist_backup [flags] list
+  todoist_backup [flags] diff [older newer]
+
+diff compares the two latest snapshots unless snapshot names are given.`
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	dir := flag.String("dir", "./backups", "local directory to keep snapshots in")
+	bucket := flag.String("s3-bucket", "", "keep snapshots in this S3 bucket instead of a local directory")
+	prefix := flag.String("s3-prefix", "todoist", "key prefix for snapshots in the S3 bucket")
+	region := flag.String("s3-region", "eu-central-1", "S3 region")
+	endpoint := flag.String("s3-endpoint", "", "endpoint of S3-compatible storage")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("error loading .env file, %v", err)
+	}
+
+	var store backup.Store = backup.NewLocalStore(*dir)
+	if *bucket != "" {
+		store, err = backup.NewS3Store(backup.S3StoreConfig{
+			Bucket:   *bucket,
+			Prefix:   *prefix,
+			Region:   *region,
+			Endpoint: *endpoint,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	switch flag.Arg(0) {
+	case "save":
+		resp, err := api.BackupTodoist(os.Getenv("TODOIST_API_TOKEN"), store)
+		if err != nil {
+			log.Fatalf("error saving snapshot, %v", err)
+		}
+		log.Printf("saved %s with %d projects and %d tasks", resp.Name, resp.ProjectCount, resp.TaskCount)
+	case "list":
+		names, err := store.List()
+		if err != nil {
+			log.Fatalf("error listing snapshots, %v", err)
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+	case "diff":
+		diff, err := api.DiffTodoistSnapshots(store, flag.Arg(1), flag.Arg(2))
+		if err != nil {
+			log.Fatalf("error comparing snapshots, %v", err)
+		}
+		fmt.Print(todoist.PrettyDiffOutput(*diff))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -2,22 +2,73 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"encore.dev/cron"
+	"errors"
 	"github.com/valeriikundas/todoist-scripts/api"
+	"github.com/valeriikundas/todoist-scripts/backup"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+	"github.com/valeriikundas/todoist-scripts/toggl"
+	"github.com/valeriikundas/todoist-scripts/utils"
+	"io"
 	"log"
+	"net/http"
 )
 
 var secrets struct {
 	TodoistApiToken string
+	// TodoistClientSecret is the secret of the Todoist app that webhooks are registered for.
+	TodoistClientSecret string
 
 	TelegramApiToken string
 	TelegramUserID   string
 
 	TogglApiToken    string
 	TogglWorkspaceID string
+	// TogglAutoCreate is "true" to create Toggl projects and tags named in replies that match none.
+	TogglAutoCreate string
+	// TogglProjectMap is a `;`-separated list of `todoist project=toggl project` pairs used for timers started from tasks.
+	TogglProjectMap string
+	// TogglSyncAreas and TogglSyncLabels are comma separated parent projects and labels selecting the Todoist
+	// projects mirrored into Toggl. Both empty disable the sync.
+	TogglSyncAreas  string
+	TogglSyncLabels string
+
+	// Timezone overrides the timezone from Todoist user settings, e.g. "Europe/Kyiv".
+	Timezone string
+	// WorkingHours is the "start-end" window in which Toggl entries are asked for, 8-24 by default.
+	WorkingHours string
 
 	// todo: can be rewritten with https://encore.dev/docs/develop/config
 	ExcludeFromZeroProjectsList []string
+	// NextActionProjectLimits is a `;`-separated list of `project=limit` pairs.
+	NextActionProjectLimits string
+	// NextActionSectionLimits is a `;`-separated list of `project/section=limit` pairs.
+	NextActionSectionLimits string
+	CheckZeroPerSection     string
+	// NextActionGlobalLimit caps @next_action tasks across all projects, empty disables the cap.
+	NextActionGlobalLimit string
+	// NextActionAreaLimits is a `;`-separated list of `project=limit` caps on parent project subtrees.
+	NextActionAreaLimits string
+	// EnforceNextActionLimits is an ordering such as "priority,due,age" used to demote excess
+	// @next_action tasks to DemoteLabel. Empty only reports.
+	EnforceNextActionLimits string
+	DemoteLabel             string
+	// PromoteNextActions is "order" or "priority" to add @next_action to projects without one. Empty only reports.
+	PromoteNextActions string
+	// TaskLintRules is a comma separated list of lint rules, or "all". Empty disables task linting.
+	TaskLintRules     string
+	TaskLintLanguages string
+	// TaskLintVerbs replaces verb lists per language in the `en=call,email;uk=купити` format.
+	TaskLintVerbs string
+	// ProjectLint is a JSON todoist.ProjectLintConfig. Empty disables project linting.
+	ProjectLint string
+	// InboxProtectedPriority is the lowest priority, e.g. "p2", that Inbox archiving never moves. Empty keeps p1 and p2.
+	InboxProtectedPriority string
+
+	BackupS3Bucket   string
+	BackupS3Region   string
+	BackupS3Endpoint string
 }
 
 //encore:service
@@ -45,37 +96,413 @@ var _ = cron.NewJob("older-tasks-archiver", cron.JobConfig{
 	Endpoint: ArchiveOlderTasksEndpoint,
 })
 
+// Remind about a growing Inbox, more often the larger and older it gets.
+var _ = cron.NewJob("inbox-reminder", cron.JobConfig{
+	Title:    "Send Telegram reminder when Inbox crosses count or age thresholds",
+	Schedule: "0 * * * *",
+	Endpoint: SendInboxReminderEndpoint,
+})
+
+// Save a snapshot of the whole Todoist account.
+var _ = cron.NewJob("todoist-backup", cron.JobConfig{
+	Title:    "Save a JSON snapshot of all Todoist projects, sections, tasks, labels and comments",
+	Schedule: "0 3 * * *",
+	Endpoint: BackupTodoistEndpoint,
+})
+
 // Ask for Toggl time entry if it is empty.
 var _ = cron.NewJob("ask-for-toggl-entry", cron.JobConfig{
 	Title:    "Ask for Toggl time entry through Telegram if it is empty. Save to Toggl",
-	Schedule: "*/15 5-21 * * *", // Every 15 minutes from 5-21 UTC
+	Schedule: "*/15 * * * *", // limited to working hours in the user's timezone by the endpoint
 	Endpoint: AssertRunningTogglEntryEndpoint,
 })
 
+// Mirror selected Todoist projects into Toggl projects.
+var _ = cron.NewJob("toggl-projects-sync", cron.JobConfig{
+	Title:    "Create, rename and archive Toggl projects to mirror selected Todoist projects",
+	Schedule: "30 3 * * *",
+	Endpoint: SyncTogglProjectsEndpoint,
+})
+
+// Send weekly productivity summary to Telegram.
+var _ = cron.NewJob("productivity-stats-notifier", cron.JobConfig{
+	Title:    "Send Telegram message with productivity statistics for the last 7 days",
+	Schedule: "0 18 * * 0",
+	Endpoint: SendProductivityStatsEndpoint,
+})
+
+// Send a digest of delegated tasks per person.
+var _ = cron.NewJob("delegation-digest", cron.JobConfig{
+	Title:    "Send Telegram digest of tasks delegated to each collaborator and tasks assigned to me",
+	Schedule: "0 7 * * 1",
+	Endpoint: SendDelegationDigestEndpoint,
+})
+
+// Propose archiving projects without open tasks.
+var _ = cron.NewJob("dormant-projects-archiver", cron.JobConfig{
+	Title:    "Ask on Telegram to archive projects without open tasks or activity for 30 days",
+	Schedule: "0 8 * * 6",
+	Endpoint: ProposeArchivingDormantProjectsEndpoint,
+})
+
 //encore:api private method=GET path=/projects/incorrect
 func (s *Service) GetIncorrectProjectsEndpoint(ctx context.Context) (*api.IncorrectResponse, error) {
-	excludeFromZeroProjectsList := secrets.ExcludeFromZeroProjectsList
+	checkConfig, err := nextActionCheckConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
 	return api.SendReportAboutIncorrectProjectsToTelegram(
 		secrets.TodoistApiToken,
 		secrets.TelegramApiToken,
 		secrets.TelegramUserID,
-		excludeFromZeroProjectsList,
+		*checkConfig,
+		options...,
+	)
+}
+
+func nextActionCheckConfig() (*todoist.NextActionCheckConfig, error) {
+	projectLimits, err := utils.ParseProjectLimits(secrets.NextActionProjectLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionLimits, err := utils.ParseProjectLimits(secrets.NextActionSectionLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	areaLimits, err := utils.ParseProjectLimits(secrets.NextActionAreaLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	globalLimit, err := utils.ParseGlobalLimit(secrets.NextActionGlobalLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	enforcement, err := todoist.ParseWIPEnforcementConfig(secrets.EnforceNextActionLimits, secrets.DemoteLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	promotion, err := todoist.ParseNextActionPromotionConfig(secrets.PromoteNextActions)
+	if err != nil {
+		return nil, err
+	}
+
+	lint, err := todoist.ParseTaskLintConfig(secrets.TaskLintRules, secrets.TaskLintLanguages, secrets.TaskLintVerbs)
+	if err != nil {
+		return nil, err
+	}
+
+	projectLint, err := todoist.ParseProjectLintConfig(secrets.ProjectLint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &todoist.NextActionCheckConfig{
+		Limit:                       1,
+		ProjectLimits:               projectLimits,
+		ExcludeFromZeroProjectsList: secrets.ExcludeFromZeroProjectsList,
+		SectionLimits:               sectionLimits,
+		CheckZeroPerSection:         secrets.CheckZeroPerSection == "true",
+		GlobalLimit:                 globalLimit,
+		AreaLimits:                  areaLimits,
+		Enforcement:                 enforcement,
+		Promotion:                   promotion,
+		Lint:                        lint,
+		ProjectLint:                 projectLint,
+	}, nil
+}
+
+// TodoistWebhookEndpoint receives Todoist webhooks. It is public as Todoist authenticates with an HMAC signature.
+//
+//encore:api public raw method=POST path=/webhooks/todoist
+func (s *Service) TodoistWebhookEndpoint(w http.ResponseWriter, req *http.Request) {
+	checkConfig, err := nextActionCheckConfig()
+	if err != nil {
+		log.Print(err)
+		http.Error(w, "invalid config", http.StatusInternalServerError)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := api.HandleTodoistWebhook(
+		req.Context(),
+		api.WebhookConfig{
+			TodoistApiToken:     secrets.TodoistApiToken,
+			TodoistClientSecret: secrets.TodoistClientSecret,
+			TelegramApiToken:    secrets.TelegramApiToken,
+			TelegramUserID:      secrets.TelegramUserID,
+			CheckConfig:         *checkConfig,
+		},
+		req.Header.Get("X-Todoist-Hmac-SHA256"),
+		req.Header.Get("X-Todoist-Delivery-ID"),
+		body,
 	)
+	if errors.Is(err, api.ErrInvalidWebhookSignature) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		log.Print(err)
+		http.Error(w, "failed to handle webhook", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
 //encore:api private method=POST path=/tasks/archive-older
-func (s *Service) ArchiveOlderTasksEndpoint(ctx context.Context) (*api.MoveOlderTasksResponse, error) {
-	return api.ArchiveOlderInboxTasks(secrets.TodoistApiToken)
+func (s *Service) ArchiveOlderTasksEndpoint(ctx context.Context) (*api.MoveInactiveInboxTasksResponse, error) {
+	config, err := todoist.ParseInboxMonitorConfig(secrets.InboxProtectedPriority)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ArchiveInactiveInboxTasks(secrets.TodoistApiToken, config, options...)
+}
+
+//encore:api private method=POST path=/inbox/remind
+func (s *Service) SendInboxReminderEndpoint(ctx context.Context) (*api.InboxReminderResponse, error) {
+	config, err := todoist.ParseInboxMonitorConfig(secrets.InboxProtectedPriority)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.SendInboxReminderToTelegram(
+		secrets.TodoistApiToken,
+		secrets.TelegramApiToken,
+		secrets.TelegramUserID,
+		config,
+		options...,
+	)
+}
+
+//encore:api private method=POST path=/backup
+func (s *Service) BackupTodoistEndpoint(ctx context.Context) (*api.BackupResponse, error) {
+	store, err := backup.NewS3Store(backup.S3StoreConfig{
+		Bucket:   secrets.BackupS3Bucket,
+		Prefix:   "todoist",
+		Region:   secrets.BackupS3Region,
+		Endpoint: secrets.BackupS3Endpoint,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return api.BackupTodoist(secrets.TodoistApiToken, store)
 }
 
 // FIXME: refactor log.Fatal to returning errors to callers in whole project
 
 //encore:api private method=POST path=/toggl/assertRunningEntry
 func (s *Service) AssertRunningTogglEntryEndpoint(ctx context.Context) (*api.AssertToggleEntryResponse, error) {
+	location, err := api.UserLocation(secrets.TodoistApiToken, secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	workingHours, err := utils.ParseWorkingHours(secrets.WorkingHours)
+	if err != nil {
+		return nil, err
+	}
+
 	return api.AssertRunningTogglEntry(
 		secrets.TogglApiToken,
 		secrets.TogglWorkspaceID,
 		secrets.TelegramApiToken,
 		secrets.TelegramUserID,
+		location,
+		workingHours,
+		toggl.StartOptions{AutoCreate: secrets.TogglAutoCreate == "true"},
+	)
+}
+
+//encore:api private method=GET path=/stats
+func (s *Service) GetProductivityStatsEndpoint(ctx context.Context) (*todoist.ProductivityStats, error) {
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetProductivityStats(secrets.TodoistApiToken, 30, options...)
+}
+
+//encore:api private method=POST path=/stats/notify
+func (s *Service) SendProductivityStatsEndpoint(ctx context.Context) (*todoist.ProductivityStats, error) {
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.SendProductivityStatsToTelegram(
+		secrets.TodoistApiToken,
+		secrets.TelegramApiToken,
+		secrets.TelegramUserID,
+		7,
+		options...,
+	)
+}
+
+//encore:api private method=GET path=/tasks/delegated
+func (s *Service) GetDelegatedTasksEndpoint(ctx context.Context) (*api.DelegationResponse, error) {
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetDelegatedTasks(secrets.TodoistApiToken, options...)
+}
+
+//encore:api private method=POST path=/tasks/delegated/notify
+func (s *Service) SendDelegationDigestEndpoint(ctx context.Context) (*api.DelegationResponse, error) {
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.SendDelegationDigestToTelegram(
+		secrets.TodoistApiToken,
+		secrets.TelegramApiToken,
+		secrets.TelegramUserID,
+		options...,
+	)
+}
+
+//encore:api private method=POST path=/projects/dormant/propose
+func (s *Service) ProposeArchivingDormantProjectsEndpoint(ctx context.Context) (*api.DormantProjectsResponse, error) {
+	store, err := backup.NewS3Store(backup.S3StoreConfig{
+		Bucket:   secrets.BackupS3Bucket,
+		Prefix:   "dormant",
+		Region:   secrets.BackupS3Region,
+		Endpoint: secrets.BackupS3Endpoint,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ProposeArchivingDormantProjects(
+		secrets.TodoistApiToken,
+		secrets.TelegramApiToken,
+		secrets.TelegramUserID,
+		todoist.DormantProjectsConfig{
+			DormantAfterDays: api.DefaultDormantAfterDays,
+			Allowlist:        secrets.ExcludeFromZeroProjectsList,
+		},
+		store,
+		options...,
+	)
+}
+
+type StartTimerParams struct {
+	// TaskID is the Todoist task to start a timer for.
+	TaskID string `json:"taskID"`
+	// Filter is a Todoist filter query, the first matching task is used when TaskID is empty.
+	Filter string `json:"filter"`
+}
+
+//encore:api private method=POST path=/timer/start
+func (s *Service) StartTimerEndpoint(ctx context.Context, params *StartTimerParams) (*api.StartTimerResponse, error) {
+	config, err := timerConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case params.TaskID != "":
+		return api.StartTimerForTask(secrets.TodoistApiToken, *config, params.TaskID)
+	case params.Filter != "":
+		return api.StartTimerForFilter(secrets.TodoistApiToken, *config, params.Filter)
+	default:
+		return nil, errors.New("taskID or filter is required")
+	}
+}
+
+//encore:api private method=POST path=/timer/propose
+func (s *Service) ProposeTimerEndpoint(ctx context.Context) (*api.TimerProposalResponse, error) {
+	return api.ProposeTimerForNextActions(
+		secrets.TodoistApiToken,
+		secrets.TelegramApiToken,
+		secrets.TelegramUserID,
+	)
+}
+
+func timerConfig() (*api.TimerConfig, error) {
+	projectMap, err := utils.ParseNameMap(secrets.TogglProjectMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.TimerConfig{
+		TogglApiToken:    secrets.TogglApiToken,
+		TogglWorkspaceID: secrets.TogglWorkspaceID,
+		ProjectMap:       projectMap,
+		StartOptions:     toggl.StartOptions{AutoCreate: secrets.TogglAutoCreate == "true"},
+	}, nil
+}
+
+//encore:api private method=POST path=/toggl/projects/sync
+func (s *Service) SyncTogglProjectsEndpoint(ctx context.Context) (*api.TogglProjectSyncResponse, error) {
+	return syncTogglProjects(false)
+}
+
+// PlanTogglProjectSyncEndpoint lists planned creates, renames and archives without changing Toggl.
+//
+//encore:api private method=GET path=/toggl/projects/sync/plan
+func (s *Service) PlanTogglProjectSyncEndpoint(ctx context.Context) (*api.TogglProjectSyncResponse, error) {
+	return syncTogglProjects(true)
+}
+
+func syncTogglProjects(dryRun bool) (*api.TogglProjectSyncResponse, error) {
+	if secrets.TogglSyncAreas == "" && secrets.TogglSyncLabels == "" {
+		return &api.TogglProjectSyncResponse{DryRun: dryRun, Actions: []api.TogglProjectAction{}}, nil
+	}
+
+	store, err := backup.NewS3Store(backup.S3StoreConfig{
+		Bucket:   secrets.BackupS3Bucket,
+		Prefix:   "toggl",
+		Region:   secrets.BackupS3Region,
+		Endpoint: secrets.BackupS3Endpoint,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	config := api.ParseTogglProjectSyncConfig(
+		secrets.TogglApiToken,
+		secrets.TogglWorkspaceID,
+		secrets.TogglSyncAreas,
+		secrets.TogglSyncLabels,
+		dryRun,
 	)
+	return api.SyncTogglProjects(secrets.TodoistApiToken, config, store)
 }
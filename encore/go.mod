@@ -9,4 +9,12 @@ require (
 	github.com/valeriikundas/todoist-scripts v0.0.0-20231130152949-6426a3e7a29f
 )
 
-require github.com/google/uuid v1.4.0 // indirect
+require (
+	github.com/Masterminds/semver/v3 v3.2.1 // indirect
+	github.com/aws/aws-sdk-go v1.49.9 // indirect
+	github.com/aws/jsii-runtime-go v1.93.0 // indirect
+	github.com/google/uuid v1.4.0 // indirect
+	github.com/jmespath/go-jmespath v0.4.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
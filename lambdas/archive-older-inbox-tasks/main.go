@@ -6,7 +6,17 @@ import (
 )
 
 func f(secrets *lambdacommon.Secrets) (*api.MoveInactiveInboxTasksResponse, error) {
-	return api.ArchiveInactiveInboxTasks(secrets.TodoistApiToken)
+	config, err := lambdacommon.ReadInboxMonitorConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ArchiveInactiveInboxTasks(secrets.TodoistApiToken, config, options...)
 }
 
 func main() {
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+
+	"github.com/valeriikundas/todoist-scripts/api"
+	"github.com/valeriikundas/todoist-scripts/backup"
+	lambdacommon "github.com/valeriikundas/todoist-scripts/lambdas"
+)
+
+func f(secrets *lambdacommon.Secrets) (*api.BackupResponse, error) {
+	store, err := backup.NewS3Store(backup.S3StoreConfig{
+		Bucket: os.Getenv("BackupBucket"),
+		Prefix: "todoist",
+		Region: os.Getenv("AWS_REGION"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return api.BackupTodoist(secrets.TodoistApiToken, store)
+}
+
+func main() {
+	lambdacommon.Run(f)
+}
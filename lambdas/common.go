@@ -20,6 +20,18 @@ func Run[R any](f func(secrets *Secrets) (*R, error)) {
 	runWithEnv(withHandler(f))
 }
 
+// RunWithRequest is like Run for handlers that need the HTTP request, e.g. webhooks.
+func RunWithRequest[R any](f func(secrets *Secrets, request events.APIGatewayProxyRequest) (*R, error)) {
+	runWithEnv(func(
+		ctx context.Context,
+		request events.APIGatewayProxyRequest,
+	) (events.APIGatewayProxyResponse, error) {
+		return withSetup(func(secrets *Secrets) (*R, error) {
+			return f(secrets, request)
+		})
+	})
+}
+
 func withHandler[R any](f func(secrets *Secrets) (*R, error)) func(
 	ctx context.Context,
 	request events.APIGatewayProxyRequest,
@@ -73,6 +85,9 @@ func withSetup[R any](f func(secrets *Secrets) (R, error)) (
 	}
 
 	resp, err := f(secrets)
+	if errors.Cause(err) == ErrUnauthorized {
+		return events.APIGatewayProxyResponse{StatusCode: 401}, nil
+	}
 	if err != nil {
 		return empty500Response(), err
 	}
@@ -86,12 +101,19 @@ func empty500Response() events.APIGatewayProxyResponse {
 	}
 }
 
+// ErrUnauthorized makes the handler respond with 401 instead of 500.
+var ErrUnauthorized = errors.New("unauthorized")
+
 const region = "eu-central-1"
 
 type Secrets struct {
 	TodoistApiToken  string
 	TelegramApiToken string
 	TelegramUserID   string
+	// TodoistClientSecret is the secret of the Todoist app that webhooks are registered for.
+	TodoistClientSecret string
+	// Timezone overrides the timezone from Todoist user settings, e.g. "Europe/Kyiv".
+	Timezone string
 }
 
 func readSecrets() (*Secrets, error) {
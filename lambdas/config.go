@@ -0,0 +1,77 @@
+package lambdacommon
+
+import (
+	"github.com/pkg/errors"
+	"github.com/valeriikundas/todoist-scripts/todoist"
+	"github.com/valeriikundas/todoist-scripts/utils"
+	"os"
+	"strings"
+)
+
+// ReadInboxMonitorConfig reads the Inbox archive and reminder settings from the environment set up by cdk.go.
+func ReadInboxMonitorConfig() (todoist.InboxMonitorConfig, error) {
+	return todoist.ParseInboxMonitorConfig(os.Getenv("InboxProtectedPriority"))
+}
+
+// ReadNextActionCheckConfig reads the @next_action check settings from the environment set up by cdk.go.
+func ReadNextActionCheckConfig() (*todoist.NextActionCheckConfig, error) {
+	excludeFromZeroProjectsString, ok := os.LookupEnv("ExcludeFromZeroProjectsList")
+	if !ok {
+		return nil, errors.New("ExcludeFromZeroProjectsList environment variable is not set")
+	}
+	excludeFromZeroProjectsList := strings.Split(excludeFromZeroProjectsString, ";")
+
+	projectLimits, err := utils.ParseProjectLimits(os.Getenv("NextActionProjectLimits"))
+	if err != nil {
+		return nil, err
+	}
+
+	sectionLimits, err := utils.ParseProjectLimits(os.Getenv("NextActionSectionLimits"))
+	if err != nil {
+		return nil, err
+	}
+
+	areaLimits, err := utils.ParseProjectLimits(os.Getenv("NextActionAreaLimits"))
+	if err != nil {
+		return nil, err
+	}
+
+	globalLimit, err := utils.ParseGlobalLimit(os.Getenv("NextActionGlobalLimit"))
+	if err != nil {
+		return nil, err
+	}
+
+	enforcement, err := todoist.ParseWIPEnforcementConfig(os.Getenv("EnforceNextActionLimits"), os.Getenv("DemoteLabel"))
+	if err != nil {
+		return nil, err
+	}
+
+	promotion, err := todoist.ParseNextActionPromotionConfig(os.Getenv("PromoteNextActions"))
+	if err != nil {
+		return nil, err
+	}
+
+	lint, err := todoist.ParseTaskLintConfig(os.Getenv("TaskLintRules"), os.Getenv("TaskLintLanguages"), os.Getenv("TaskLintVerbs"))
+	if err != nil {
+		return nil, err
+	}
+
+	projectLint, err := todoist.ParseProjectLintConfig(os.Getenv("ProjectLint"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &todoist.NextActionCheckConfig{
+		Limit:                       1,
+		ProjectLimits:               projectLimits,
+		ExcludeFromZeroProjectsList: excludeFromZeroProjectsList,
+		SectionLimits:               sectionLimits,
+		CheckZeroPerSection:         os.Getenv("CheckZeroPerSection") == "true",
+		GlobalLimit:                 globalLimit,
+		AreaLimits:                  areaLimits,
+		Enforcement:                 enforcement,
+		Promotion:                   promotion,
+		Lint:                        lint,
+		ProjectLint:                 projectLint,
+	}, nil
+}
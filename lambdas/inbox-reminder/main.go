@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/valeriikundas/todoist-scripts/api"
+	lambdacommon "github.com/valeriikundas/todoist-scripts/lambdas"
+)
+
+func f(secrets *lambdacommon.Secrets) (*api.InboxReminderResponse, error) {
+	config, err := lambdacommon.ReadInboxMonitorConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.SendInboxReminderToTelegram(
+		secrets.TodoistApiToken,
+		secrets.TelegramApiToken,
+		secrets.TelegramUserID,
+		config,
+		options...,
+	)
+}
+
+func main() {
+	lambdacommon.Run(f)
+}
@@ -1,25 +1,27 @@
 package main
 
 import (
-	"github.com/pkg/errors"
 	"github.com/valeriikundas/todoist-scripts/api"
 	lambdacommon "github.com/valeriikundas/todoist-scripts/lambdas"
-	"os"
-	"strings"
 )
 
 func f(secrets *lambdacommon.Secrets) (*api.IncorrectResponse, error) {
-	excludeFromZeroProjectsString, ok := os.LookupEnv("ExcludeFromZeroProjectsList")
-	if !ok {
-		return nil, errors.New("ExcludeFromZeroProjectsList environment variable is not set")
+	checkConfig, err := lambdacommon.ReadNextActionCheckConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := api.TimezoneOptions(secrets.Timezone)
+	if err != nil {
+		return nil, err
 	}
-	excludeFromZeroProjectsList := strings.Split(excludeFromZeroProjectsString, ";")
 
 	return api.SendReportAboutIncorrectProjectsToTelegram(
 		secrets.TodoistApiToken,
 		secrets.TelegramApiToken,
 		secrets.TelegramUserID,
-		excludeFromZeroProjectsList,
+		*checkConfig,
+		options...,
 	)
 }
 
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pkg/errors"
+	"github.com/valeriikundas/todoist-scripts/api"
+	lambdacommon "github.com/valeriikundas/todoist-scripts/lambdas"
+	"strings"
+)
+
+func f(secrets *lambdacommon.Secrets, request events.APIGatewayProxyRequest) (*api.WebhookResponse, error) {
+	checkConfig, err := lambdacommon.ReadNextActionCheckConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	body := []byte(request.Body)
+	if request.IsBase64Encoded {
+		body, err = base64.StdEncoding.DecodeString(request.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode request body")
+		}
+	}
+
+	resp, err := api.HandleTodoistWebhook(
+		context.Background(),
+		api.WebhookConfig{
+			TodoistApiToken:     secrets.TodoistApiToken,
+			TodoistClientSecret: secrets.TodoistClientSecret,
+			TelegramApiToken:    secrets.TelegramApiToken,
+			TelegramUserID:      secrets.TelegramUserID,
+			CheckConfig:         *checkConfig,
+		},
+		header(request, "X-Todoist-Hmac-SHA256"),
+		header(request, "X-Todoist-Delivery-ID"),
+		body,
+	)
+	if errors.Is(err, api.ErrInvalidWebhookSignature) {
+		return nil, lambdacommon.ErrUnauthorized
+	}
+	return resp, err
+}
+
+// header looks up a header case-insensitively, as function URLs lowercase header names.
+func header(request events.APIGatewayProxyRequest, key string) string {
+	for k, v := range request.Headers {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+func main() {
+	lambdacommon.RunWithRequest(f)
+}
@@ -14,7 +14,10 @@ import (
 	"net/url"
 )
 
-const ParseModeMarkdownV2 = "MarkdownV2"
+const (
+	ParseModeMarkdownV2 = "MarkdownV2"
+	ParseModeNone       = ""
+)
 
 type SendError struct {
 	ErrorCode   int    `json:"error_code"`
@@ -34,40 +37,96 @@ func NewTelegram(apiToken string) Telegram {
 }
 
 func (t *Telegram) Send(chatID int, message string, parseMode string) error {
-	sendUrl := url.URL{
-		Scheme: "https",
-		Host:   "api.telegram.org",
-		Path:   fmt.Sprintf("bot%s/sendMessage", t.apiToken),
+	return t.SendWithButtons(chatID, message, parseMode, nil)
+}
+
+// InlineButton is a button attached to a message. Pressing it sends a callback query with CallbackData.
+type InlineButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+// SendWithButtons sends a message with rows of inline buttons below it.
+func (t *Telegram) SendWithButtons(chatID int, message string, parseMode string, buttons [][]InlineButton) error {
+	var replyMarkup any
+	if len(buttons) > 0 {
+		replyMarkup = struct {
+			InlineKeyboard [][]InlineButton `json:"inline_keyboard"`
+		}{InlineKeyboard: buttons}
 	}
+	return t.sendMessage(chatID, message, parseMode, replyMarkup)
+}
 
-	message = addBacklash(message)
+// SendForceReply sends a message that the Telegram app opens a reply to, so that the answer carries
+// Message.ReplyToMessage.
+func (t *Telegram) SendForceReply(chatID int, message string, parseMode string) error {
+	replyMarkup := struct {
+		ForceReply bool `json:"force_reply"`
+	}{ForceReply: true}
+	return t.sendMessage(chatID, message, parseMode, replyMarkup)
+}
+
+func (t *Telegram) sendMessage(chatID int, message string, parseMode string, replyMarkup any) error {
+	if parseMode == ParseModeMarkdownV2 {
+		message = addBacklash(message)
+	}
 
 	requestData := struct {
-		ChatID    int    `json:"chat_id"`
-		Text      string `json:"text"`
-		ParseMode string `json:"parse_mode"`
+		ChatID      int    `json:"chat_id"`
+		Text        string `json:"text"`
+		ParseMode   string `json:"parse_mode,omitempty"`
+		ReplyMarkup any    `json:"reply_markup,omitempty"`
 	}{
-		ChatID:    chatID,
-		Text:      message,
-		ParseMode: parseMode,
+		ChatID:      chatID,
+		Text:        message,
+		ParseMode:   parseMode,
+		ReplyMarkup: replyMarkup,
 	}
 
-	b, err := json.Marshal(requestData)
+	b, err := t.post("sendMessage", requestData)
 	if err != nil {
-		return errors.Wrap(err, "failed to marshal telegram message")
+		return err
+	}
+
+	log.Printf("sent telegram message: %s", string(b))
+	return nil
+}
+
+// AnswerCallbackQuery stops the loading indicator on a pressed button and shows text as a notification.
+func (t *Telegram) AnswerCallbackQuery(callbackQueryID string, text string) error {
+	requestData := struct {
+		CallbackQueryID string `json:"callback_query_id"`
+		Text            string `json:"text,omitempty"`
+	}{
+		CallbackQueryID: callbackQueryID,
+		Text:            text,
+	}
+
+	_, err := t.post("answerCallbackQuery", requestData)
+	return err
+}
+
+func (t *Telegram) post(method string, requestData any) ([]byte, error) {
+	sendUrl := url.URL{
+		Scheme: "https",
+		Host:   "api.telegram.org",
+		Path:   fmt.Sprintf("bot%s/%s", t.apiToken, method),
 	}
 
-	requestBody := bytes.NewReader(b)
+	b, err := json.Marshal(requestData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal telegram %s request", method)
+	}
 
-	// fixme: use post request
-	resp, err := http.Post(sendUrl.String(), "application/json", requestBody)
+	resp, err := http.Post(sendUrl.String(), "application/json", bytes.NewReader(b))
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer resp.Body.Close()
 
 	b, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var body struct {
@@ -77,17 +136,16 @@ func (t *Telegram) Send(chatID int, message string, parseMode string) error {
 	}
 	err = json.Unmarshal(b, &body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if body.ErrorCode != 0 {
-		return SendError{
+		return nil, SendError{
 			ErrorCode:   body.ErrorCode,
 			Description: body.Description,
 		}
 	}
 
-	log.Printf("sent telegram message: %s", string(b))
-	return nil
+	return b, nil
 }
 
 // addBacklash escapes special characters in the given string.
@@ -105,6 +163,20 @@ func addBacklash(s string) string {
 	return b.String()
 }
 
+// EscapeText escapes MarkdownV2 characters that Send leaves as formatting, so that
+// user-provided text such as task content can be embedded in a MarkdownV2 message.
+func EscapeText(s string) string {
+	b := strings.Builder{}
+	escapeChars := []rune{'\\', '*', '[', ']', '(', ')', '~', '`', '>', '#', '+', '=', '|', '{', '}', '!'}
+	for _, c := range s {
+		if slices.Contains(escapeChars, c) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
 func (t *Telegram) Ask(chatID string, query string) error {
 	// todo: send a telegram message expecting a simple text reply
 	return errors.New("not implemented")
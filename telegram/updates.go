@@ -0,0 +1,80 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type Update struct {
+	ID      int     `json:"update_id"`
+	Message Message `json:"message"`
+	// CallbackQuery is set instead of Message when an inline button was pressed.
+	CallbackQuery *CallbackQuery `json:"callback_query"`
+}
+
+type Message struct {
+	ID   int    `json:"message_id"`
+	Chat Chat   `json:"chat"`
+	Text string `json:"text"`
+	Date int    `json:"date"`
+	// ReplyToMessage is the message this one replies to, without its own ReplyToMessage.
+	ReplyToMessage *Message `json:"reply_to_message"`
+}
+
+type Chat struct {
+	ID int `json:"id"`
+}
+
+type CallbackQuery struct {
+	ID      string   `json:"id"`
+	Message *Message `json:"message"`
+	Data    string   `json:"data"`
+}
+
+// GetUpdates long-polls the Bot API for updates with IDs starting at offset.
+func (t *Telegram) GetUpdates(offset int, timeoutSeconds int) ([]Update, error) {
+	query := url.Values{}
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("timeout", strconv.Itoa(timeoutSeconds))
+	query.Set("allowed_updates", `["message","callback_query"]`)
+	updatesUrl := url.URL{
+		Scheme:   "https",
+		Host:     "api.telegram.org",
+		Path:     fmt.Sprintf("bot%s/getUpdates", t.apiToken),
+		RawQuery: query.Encode(),
+	}
+
+	resp, err := http.Get(updatesUrl.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Ok          bool     `json:"ok"`
+		Result      []Update `json:"result"`
+		ErrorCode   int      `json:"error_code"`
+		Description string   `json:"description"`
+	}
+	err = json.Unmarshal(b, &body)
+	if err != nil {
+		return nil, err
+	}
+	if !body.Ok {
+		return nil, SendError{
+			ErrorCode:   body.ErrorCode,
+			Description: body.Description,
+		}
+	}
+
+	return body.Result, nil
+}
@@ -0,0 +1,110 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// API is the data access layer used by Client. Backends exist for the legacy REST v2 + Sync v9 pair,
+// the unified v1 API and an in-memory store for tests.
+type API interface {
+	ListProjects(ctx context.Context) ([]Project, error)
+	ListTasks(ctx context.Context) ([]Task, error)
+	ListProjectTasks(ctx context.Context, projectID string) ([]Task, error)
+	// GetTask returns a task by ID, or an error wrapping ErrNotFound.
+	GetTask(ctx context.Context, taskID string) (*Task, error)
+	// FilterTasks lists open tasks matching a Todoist filter query such as "@next_action & #Work".
+	FilterTasks(ctx context.Context, query string) ([]Task, error)
+	ListSections(ctx context.Context) ([]Section, error)
+	ListLabels(ctx context.Context) ([]Label, error)
+	ListComments(ctx context.Context) ([]Comment, error)
+	ListCompletedTasks(ctx context.Context, since time.Time) ([]CompletedTask, error)
+	ListCollaborators(ctx context.Context) ([]Collaborator, error)
+	GetCurrentUser(ctx context.Context) (*User, error)
+	// ApplyCommands sends Sync commands in one request and returns an error if any of them failed.
+	ApplyCommands(ctx context.Context, commands []Command) (*SyncResponse, error)
+}
+
+type clientConfig struct {
+	httpClient *http.Client
+	pageSize   int
+	baseUrl    string
+	unified    bool
+	location   *time.Location
+}
+
+type ClientOption func(c *clientConfig)
+
+// WithPageSize sets the number of items requested per page from paginated endpoints.
+func WithPageSize(pageSize int) ClientOption {
+	return func(c *clientConfig) {
+		c.pageSize = pageSize
+	}
+}
+
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *clientConfig) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL replaces `https://api.todoist.com`, e.g. with a test server.
+func WithBaseURL(baseUrl string) ClientOption {
+	return func(c *clientConfig) {
+		c.baseUrl = baseUrl
+	}
+}
+
+// WithUnifiedAPI selects the unified v1 API instead of REST v2 + Sync v9.
+func WithUnifiedAPI() ClientOption {
+	return func(c *clientConfig) {
+		c.unified = true
+	}
+}
+
+// WithTimezone sets the user's timezone instead of loading it from Todoist user settings.
+func WithTimezone(location *time.Location) ClientOption {
+	return func(c *clientConfig) {
+		c.location = location
+	}
+}
+
+type Client struct {
+	api API
+
+	location     *time.Location
+	locationOnce sync.Once
+}
+
+// NewClient returns a client for the legacy REST v2 + Sync v9 APIs unless WithUnifiedAPI is given.
+func NewClient(apiToken string, options ...ClientOption) *Client {
+	config := clientConfig{
+		httpClient: &http.Client{},
+		pageSize:   DefaultPageSize,
+		baseUrl:    "https://api.todoist.com",
+	}
+	for _, option := range options {
+		option(&config)
+	}
+
+	h := httpAPI{
+		apiToken:   apiToken,
+		httpClient: config.httpClient,
+		pageSize:   config.pageSize,
+		baseUrl:    config.baseUrl,
+	}
+	var client *Client
+	if config.unified {
+		client = NewClientWithAPI(&UnifiedAPI{h})
+	} else {
+		client = NewClientWithAPI(&LegacyAPI{h})
+	}
+	client.location = config.location
+	return client
+}
+
+func NewClientWithAPI(api API) *Client {
+	return &Client{api: api}
+}
@@ -0,0 +1,92 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LegacyAPI reads with the REST v2 API and writes with Sync v9 commands.
+type LegacyAPI struct {
+	httpAPI
+}
+
+func (a *LegacyAPI) ListProjects(ctx context.Context) ([]Project, error) {
+	return listAll[Project](ctx, &a.httpAPI, a.baseUrl+"/rest/v2/projects")
+}
+
+func (a *LegacyAPI) ListTasks(ctx context.Context) ([]Task, error) {
+	return listAll[Task](ctx, &a.httpAPI, a.baseUrl+"/rest/v2/tasks")
+}
+
+func (a *LegacyAPI) ListProjectTasks(ctx context.Context, projectID string) ([]Task, error) {
+	return listAll[Task](ctx, &a.httpAPI, a.baseUrl+"/rest/v2/tasks?project_id="+url.QueryEscape(projectID))
+}
+
+func (a *LegacyAPI) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	return getJSON[Task](ctx, &a.httpAPI, a.baseUrl+"/rest/v2/tasks/"+url.PathEscape(taskID))
+}
+
+func (a *LegacyAPI) FilterTasks(ctx context.Context, query string) ([]Task, error) {
+	return listAll[Task](ctx, &a.httpAPI, a.baseUrl+"/rest/v2/tasks?filter="+url.QueryEscape(query))
+}
+
+func (a *LegacyAPI) ListSections(ctx context.Context) ([]Section, error) {
+	return listAll[Section](ctx, &a.httpAPI, a.baseUrl+"/rest/v2/sections")
+}
+
+func (a *LegacyAPI) ListLabels(ctx context.Context) ([]Label, error) {
+	return listAll[Label](ctx, &a.httpAPI, a.baseUrl+"/rest/v2/labels")
+}
+
+// ListComments reads task and project comments with one Sync request, as REST requires a request per task.
+func (a *LegacyAPI) ListComments(ctx context.Context) ([]Comment, error) {
+	return a.listSyncComments(ctx, a.baseUrl+"/sync/v9/sync")
+}
+
+// ListCompletedTasks pages through `completed/get_all`, which uses offsets rather than cursors.
+func (a *LegacyAPI) ListCompletedTasks(ctx context.Context, since time.Time) ([]CompletedTask, error) {
+	const pageSize = 200
+
+	completedTasks := make([]CompletedTask, 0)
+	for offset := 0; ; offset += pageSize {
+		query := url.Values{}
+		query.Set("since", since.UTC().Format("2006-01-02T15:04"))
+		query.Set("limit", strconv.Itoa(pageSize))
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("annotate_items", "true")
+		b, err := a.doGetRequest(ctx, a.baseUrl+"/sync/v9/completed/get_all?"+query.Encode())
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Items []CompletedTask `json:"items"`
+		}
+		err = json.Unmarshal(b, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		completedTasks = append(completedTasks, page.Items...)
+		if len(page.Items) < pageSize {
+			break
+		}
+	}
+
+	return completedTasks, nil
+}
+
+func (a *LegacyAPI) ListCollaborators(ctx context.Context) ([]Collaborator, error) {
+	return a.listSyncCollaborators(ctx, a.baseUrl+"/sync/v9/sync")
+}
+
+func (a *LegacyAPI) GetCurrentUser(ctx context.Context) (*User, error) {
+	return a.getSyncUser(ctx, a.baseUrl+"/sync/v9/sync")
+}
+
+func (a *LegacyAPI) ApplyCommands(ctx context.Context, commands []Command) (*SyncResponse, error) {
+	return a.applyCommands(ctx, a.baseUrl+"/sync/v9/sync", commands)
+}
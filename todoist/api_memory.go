@@ -0,0 +1,379 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryAPI keeps Todoist data in memory and applies Sync commands to it, so that client logic can be
+// exercised without network access. Applied commands are recorded in Commands.
+type MemoryAPI struct {
+	mu sync.Mutex
+
+	Projects       []Project
+	Tasks          []Task
+	Sections       []Section
+	Labels         []Label
+	Comments       []Comment
+	CompletedTasks []CompletedTask
+	Collaborators  []Collaborator
+	User           User
+	Commands       []Command
+
+	nextID int
+}
+
+// memoryCommandArgs holds the arguments of all supported commands.
+type memoryCommandArgs struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id"`
+	SectionID   string    `json:"section_id"`
+	ParentID    string    `json:"parent_id"`
+	Name        string    `json:"name"`
+	NameOld     string    `json:"name_old"`
+	NameNew     string    `json:"name_new"`
+	Color       string    `json:"color"`
+	Content     *string   `json:"content"`
+	Description *string   `json:"description"`
+	Labels      *[]string `json:"labels"`
+	Priority    *Priority `json:"priority"`
+	Due         *Due      `json:"due"`
+}
+
+func (a *MemoryAPI) ListProjects(ctx context.Context) ([]Project, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.Projects), nil
+}
+
+func (a *MemoryAPI) ListTasks(ctx context.Context) ([]Task, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.Tasks), nil
+}
+
+func (a *MemoryAPI) ListProjectTasks(ctx context.Context, projectID string) ([]Task, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	tasks := make([]Task, 0)
+	for _, task := range a.Tasks {
+		if task.ProjectID == projectID {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (a *MemoryAPI) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	task, ok := a.findTask(taskID)
+	if !ok {
+		return nil, fmt.Errorf("%w: task %s", ErrNotFound, taskID)
+	}
+	clone := *task
+	return &clone, nil
+}
+
+// FilterTasks supports only `@label` and `#project` terms joined with `&`.
+func (a *MemoryAPI) FilterTasks(ctx context.Context, query string) ([]Task, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	matchers := make([]func(task Task) bool, 0)
+	for _, part := range strings.Split(query, "&") {
+		term := strings.TrimSpace(part)
+		switch {
+		case strings.HasPrefix(term, "@"):
+			matchers = append(matchers, func(task Task) bool { return slices.Contains(task.Labels, term[1:]) })
+		case strings.HasPrefix(term, "#"):
+			matchers = append(matchers, func(task Task) bool {
+				project, ok := a.findProject(task.ProjectID)
+				return ok && strings.EqualFold(project.Name, term[1:])
+			})
+		default:
+			return nil, fmt.Errorf("unsupported filter term `%s`", term)
+		}
+	}
+
+	tasks := make([]Task, 0)
+	for _, task := range a.Tasks {
+		matches := true
+		for _, matcher := range matchers {
+			matches = matches && matcher(task)
+		}
+		if matches {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (a *MemoryAPI) ListSections(ctx context.Context) ([]Section, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.Sections), nil
+}
+
+func (a *MemoryAPI) ListLabels(ctx context.Context) ([]Label, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.Labels), nil
+}
+
+func (a *MemoryAPI) ListComments(ctx context.Context) ([]Comment, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.Comments), nil
+}
+
+func (a *MemoryAPI) ListCompletedTasks(ctx context.Context, since time.Time) ([]CompletedTask, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	completedTasks := make([]CompletedTask, 0)
+	for _, task := range a.CompletedTasks {
+		if !task.CompletedAt.Before(since) {
+			completedTasks = append(completedTasks, task)
+		}
+	}
+	return completedTasks, nil
+}
+
+func (a *MemoryAPI) ListCollaborators(ctx context.Context) ([]Collaborator, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.Collaborators), nil
+}
+
+func (a *MemoryAPI) GetCurrentUser(ctx context.Context) (*User, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	user := a.User
+	return &user, nil
+}
+
+// ApplyCommands applies commands in order. Like the Sync API, temp IDs are resolved within one call only.
+// Unlike the Sync API, the first failing command stops the batch, leaving earlier changes applied.
+func (a *MemoryAPI) ApplyCommands(ctx context.Context, commands []Command) (*SyncResponse, error) {
+	if len(commands) > syncCommandsLimit {
+		return nil, fmt.Errorf("too many sync commands: %d, limit is %d", len(commands), syncCommandsLimit)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	resp := &SyncResponse{
+		SyncStatus:    map[string]json.RawMessage{},
+		TempIDMapping: map[string]string{},
+	}
+	for _, command := range commands {
+		b, err := json.Marshal(command.Args)
+		if err != nil {
+			return nil, err
+		}
+		var args memoryCommandArgs
+		err = json.Unmarshal(b, &args)
+		if err != nil {
+			return nil, err
+		}
+		resolveTempIDs(&args, resp.TempIDMapping)
+
+		id, err := a.applyCommand(command.Type, args)
+		if err != nil {
+			return nil, fmt.Errorf("sync command type=%s uuid=%s failed: %w", command.Type, command.Uuid, err)
+		}
+		if command.TempID != "" {
+			resp.TempIDMapping[command.TempID] = id
+		}
+		resp.SyncStatus[command.Uuid] = json.RawMessage(`"ok"`)
+		a.Commands = append(a.Commands, command)
+	}
+
+	return resp, nil
+}
+
+func resolveTempIDs(args *memoryCommandArgs, tempIDMapping map[string]string) {
+	for _, id := range []*string{&args.ID, &args.ProjectID, &args.SectionID, &args.ParentID} {
+		if realID, ok := tempIDMapping[*id]; ok {
+			*id = realID
+		}
+	}
+}
+
+// applyCommand applies one command and returns the ID of the created or changed object.
+func (a *MemoryAPI) applyCommand(commandType string, args memoryCommandArgs) (string, error) {
+	switch commandType {
+	case "project_add":
+		project := Project{
+			ID:       a.newID(),
+			ParentID: args.ParentID,
+			Name:     args.Name,
+			Color:    args.Color,
+		}
+		project.Url = "https://todoist.com/showProject?id=" + project.ID
+		a.Projects = append(a.Projects, project)
+		return project.ID, nil
+
+	case "project_archive":
+		if _, ok := a.findProject(args.ID); !ok {
+			return "", fmt.Errorf("project %s not found", args.ID)
+		}
+		archived := map[string]bool{}
+		for _, node := range NewProjectTree(a.Projects).Subtree(args.ID) {
+			archived[node.ID] = true
+		}
+		a.Projects = slices.DeleteFunc(a.Projects, func(p Project) bool { return archived[p.ID] })
+		return args.ID, nil
+
+	case "section_add":
+		if _, ok := a.findProject(args.ProjectID); !ok {
+			return "", fmt.Errorf("project %s not found", args.ProjectID)
+		}
+		section := Section{
+			ID:        a.newID(),
+			ProjectID: args.ProjectID,
+			Name:      args.Name,
+		}
+		a.Sections = append(a.Sections, section)
+		return section.ID, nil
+
+	case "item_add":
+		if _, ok := a.findProject(args.ProjectID); !ok {
+			return "", fmt.Errorf("project %s not found", args.ProjectID)
+		}
+		task := Task{
+			ID:        a.newID(),
+			ProjectID: args.ProjectID,
+			SectionID: args.SectionID,
+			ParentID:  args.ParentID,
+			CreatedAt: TimeParser{time.Now().UTC()},
+			Priority:  P4,
+		}
+		updateTask(&task, args)
+		a.Tasks = append(a.Tasks, task)
+		return task.ID, nil
+
+	case "item_update":
+		task, ok := a.findTask(args.ID)
+		if !ok {
+			return "", fmt.Errorf("task %s not found", args.ID)
+		}
+		updateTask(task, args)
+		return task.ID, nil
+
+	case "item_move":
+		task, ok := a.findTask(args.ID)
+		if !ok {
+			return "", fmt.Errorf("task %s not found", args.ID)
+		}
+		if args.ProjectID != "" {
+			task.ProjectID = args.ProjectID
+			task.SectionID = ""
+		}
+		if args.SectionID != "" {
+			task.SectionID = args.SectionID
+		}
+		return task.ID, nil
+
+	case "label_add":
+		label := Label{
+			ID:    a.newID(),
+			Name:  args.Name,
+			Color: args.Color,
+		}
+		a.Labels = append(a.Labels, label)
+		return label.ID, nil
+
+	case "label_update":
+		i := slices.IndexFunc(a.Labels, func(l Label) bool { return l.ID == args.ID })
+		if i == -1 {
+			return "", fmt.Errorf("label %s not found", args.ID)
+		}
+		if args.Name != "" {
+			a.renameTaskLabels(a.Labels[i].Name, args.Name)
+			a.Labels[i].Name = args.Name
+		}
+		if args.Color != "" {
+			a.Labels[i].Color = args.Color
+		}
+		return args.ID, nil
+
+	case "label_rename":
+		a.renameTaskLabels(args.NameOld, args.NameNew)
+		return "", nil
+
+	case "label_delete":
+		i := slices.IndexFunc(a.Labels, func(l Label) bool { return l.ID == args.ID })
+		if i == -1 {
+			return "", fmt.Errorf("label %s not found", args.ID)
+		}
+		a.renameTaskLabels(a.Labels[i].Name, "")
+		a.Labels = slices.Delete(a.Labels, i, i+1)
+		return args.ID, nil
+	}
+
+	return "", fmt.Errorf("unsupported command type %s", commandType)
+}
+
+func (a *MemoryAPI) newID() string {
+	a.nextID++
+	return "memory-" + strconv.Itoa(a.nextID)
+}
+
+func (a *MemoryAPI) findProject(id string) (*Project, bool) {
+	i := slices.IndexFunc(a.Projects, func(p Project) bool { return p.ID == id })
+	if i == -1 {
+		return nil, false
+	}
+	return &a.Projects[i], true
+}
+
+func (a *MemoryAPI) findTask(id string) (*Task, bool) {
+	i := slices.IndexFunc(a.Tasks, func(t Task) bool { return t.ID == id })
+	if i == -1 {
+		return nil, false
+	}
+	return &a.Tasks[i], true
+}
+
+// renameTaskLabels replaces a label on all tasks, or removes it when newName is empty.
+func (a *MemoryAPI) renameTaskLabels(oldName, newName string) {
+	for i := range a.Tasks {
+		labels := make([]string, 0, len(a.Tasks[i].Labels))
+		for _, label := range a.Tasks[i].Labels {
+			if label != oldName {
+				labels = append(labels, label)
+			} else if newName != "" {
+				labels = append(labels, newName)
+			}
+		}
+		a.Tasks[i].Labels = labels
+	}
+}
+
+func updateTask(task *Task, args memoryCommandArgs) {
+	if args.Content != nil {
+		task.Content = *args.Content
+	}
+	if args.Description != nil {
+		task.Description = *args.Description
+	}
+	if args.Labels != nil {
+		task.Labels = *args.Labels
+	}
+	if args.Priority != nil {
+		task.Priority = *args.Priority
+	}
+	if args.Due != nil {
+		task.Due = args.Due
+	}
+}
@@ -0,0 +1,56 @@
+package todoist
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryAPIApplyCommandsResolvesTempIDs(t *testing.T) {
+	api := &MemoryAPI{}
+	ctx := context.Background()
+
+	project := newTempIDCommand("project_add", map[string]any{"name": "Work"})
+	section := newTempIDCommand("section_add", map[string]any{"name": "Backlog", "project_id": project.TempID})
+	parent := newTempIDCommand("item_add", map[string]any{
+		"content":    "Plan",
+		"project_id": project.TempID,
+		"section_id": section.TempID,
+	})
+	subtask := newTempIDCommand("item_add", map[string]any{
+		"content":    "Draft",
+		"project_id": project.TempID,
+		"parent_id":  parent.TempID,
+	})
+
+	resp, err := api.ApplyCommands(ctx, []Command{project, section, parent, subtask})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectID := resp.TempIDMapping[project.TempID]
+	sectionID := resp.TempIDMapping[section.TempID]
+	parentID := resp.TempIDMapping[parent.TempID]
+	if len(api.Projects) != 1 || api.Projects[0].ID != projectID {
+		t.Errorf("projects = %+v, want one with ID %s", api.Projects, projectID)
+	}
+	if len(api.Sections) != 1 || api.Sections[0].ProjectID != projectID {
+		t.Errorf("sections = %+v, want one in project %s", api.Sections, projectID)
+	}
+	if got := api.Tasks[0]; got.ID != parentID || got.ProjectID != projectID || got.SectionID != sectionID {
+		t.Errorf("parent task = %+v, want ID %s in project %s and section %s", got, parentID, projectID, sectionID)
+	}
+	if got := api.Tasks[1]; got.ParentID != parentID || got.ProjectID != projectID {
+		t.Errorf("subtask = %+v, want parent %s in project %s", got, parentID, projectID)
+	}
+	if len(api.Commands) != 4 {
+		t.Errorf("recorded %d commands, want 4", len(api.Commands))
+	}
+
+	// temp IDs are resolved within one call only
+	_, err = api.ApplyCommands(ctx, []Command{
+		newCommand("item_add", map[string]any{"content": "Late", "project_id": project.TempID}),
+	})
+	if err == nil {
+		t.Error("temp ID from an earlier call was resolved")
+	}
+}
@@ -0,0 +1,202 @@
+package todoist
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// UnifiedAPI uses the unified Todoist API v1, which replaces both REST v2 and Sync v9.
+// Its models differ slightly and are converted to the shared types.
+type UnifiedAPI struct {
+	httpAPI
+}
+
+type unifiedProject struct {
+	ID           string `json:"id"`
+	ParentID     string `json:"parent_id"`
+	Name         string `json:"name"`
+	ChildOrder   int    `json:"child_order"`
+	InboxProject bool   `json:"inbox_project"`
+	Color        string `json:"color"`
+}
+
+func (p unifiedProject) toProject() Project {
+	return Project{
+		ID:             p.ID,
+		ParentID:       p.ParentID,
+		Name:           p.Name,
+		Url:            "https://app.todoist.com/app/project/" + p.ID,
+		Order:          p.ChildOrder,
+		IsInboxProject: p.InboxProject,
+		Color:          p.Color,
+	}
+}
+
+type unifiedTask struct {
+	ID          string     `json:"id"`
+	ProjectID   string     `json:"project_id"`
+	SectionID   string     `json:"section_id"`
+	ParentID    string     `json:"parent_id"`
+	Content     string     `json:"content"`
+	Description string     `json:"description"`
+	Labels      []string   `json:"labels"`
+	AddedAt     TimeParser `json:"added_at"`
+	CompletedAt TimeParser `json:"completed_at"`
+	Priority    Priority   `json:"priority"`
+	ChildOrder  int        `json:"child_order"`
+	Due         *Due       `json:"due"`
+	Responsible string     `json:"responsible_uid"`
+	AssignedBy  string     `json:"assigned_by_uid"`
+}
+
+func (t unifiedTask) toTask() Task {
+	return Task{
+		ID:          t.ID,
+		ProjectID:   t.ProjectID,
+		SectionID:   t.SectionID,
+		ParentID:    t.ParentID,
+		Content:     t.Content,
+		Description: t.Description,
+		Labels:      t.Labels,
+		CreatedAt:   t.AddedAt,
+		Priority:    t.Priority,
+		Order:       t.ChildOrder,
+		Due:         t.Due,
+		AssigneeID:  t.Responsible,
+		AssignerID:  t.AssignedBy,
+	}
+}
+
+func (t unifiedTask) toCompletedTask() CompletedTask {
+	completedTask := CompletedTask{
+		ID:          t.ID,
+		TaskID:      t.ID,
+		ProjectID:   t.ProjectID,
+		Content:     t.Content,
+		CompletedAt: t.CompletedAt,
+	}
+	completedTask.Item.Labels = t.Labels
+	completedTask.Item.AddedAt = t.AddedAt
+	return completedTask
+}
+
+type unifiedSection struct {
+	ID           string `json:"id"`
+	ProjectID    string `json:"project_id"`
+	Name         string `json:"name"`
+	SectionOrder int    `json:"section_order"`
+}
+
+type unifiedLabel struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	ItemOrder  int    `json:"item_order"`
+	IsFavorite bool   `json:"is_favorite"`
+}
+
+// listAllConverted reads all pages of an endpoint and converts items to the shared types.
+func listAllConverted[T any, R any](ctx context.Context, h *httpAPI, baseUrl string, convert func(T) R) ([]R, error) {
+	items, err := listAll[T](ctx, h, baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]R, 0, len(items))
+	for _, item := range items {
+		result = append(result, convert(item))
+	}
+	return result, nil
+}
+
+func (a *UnifiedAPI) ListProjects(ctx context.Context) ([]Project, error) {
+	return listAllConverted(ctx, &a.httpAPI, a.baseUrl+"/api/v1/projects", unifiedProject.toProject)
+}
+
+func (a *UnifiedAPI) ListTasks(ctx context.Context) ([]Task, error) {
+	return listAllConverted(ctx, &a.httpAPI, a.baseUrl+"/api/v1/tasks", unifiedTask.toTask)
+}
+
+func (a *UnifiedAPI) ListProjectTasks(ctx context.Context, projectID string) ([]Task, error) {
+	return listAllConverted(ctx, &a.httpAPI, a.baseUrl+"/api/v1/tasks?project_id="+url.QueryEscape(projectID), unifiedTask.toTask)
+}
+
+func (a *UnifiedAPI) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	task, err := getJSON[unifiedTask](ctx, &a.httpAPI, a.baseUrl+"/api/v1/tasks/"+url.PathEscape(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	converted := task.toTask()
+	return &converted, nil
+}
+
+func (a *UnifiedAPI) FilterTasks(ctx context.Context, query string) ([]Task, error) {
+	return listAllConverted(ctx, &a.httpAPI, a.baseUrl+"/api/v1/tasks/filter?query="+url.QueryEscape(query), unifiedTask.toTask)
+}
+
+func (a *UnifiedAPI) ListSections(ctx context.Context) ([]Section, error) {
+	return listAllConverted(ctx, &a.httpAPI, a.baseUrl+"/api/v1/sections", func(s unifiedSection) Section {
+		return Section{
+			ID:        s.ID,
+			ProjectID: s.ProjectID,
+			Name:      s.Name,
+			Order:     s.SectionOrder,
+		}
+	})
+}
+
+func (a *UnifiedAPI) ListLabels(ctx context.Context) ([]Label, error) {
+	return listAllConverted(ctx, &a.httpAPI, a.baseUrl+"/api/v1/labels", func(l unifiedLabel) Label {
+		return Label{
+			ID:         l.ID,
+			Name:       l.Name,
+			Color:      l.Color,
+			Order:      l.ItemOrder,
+			IsFavorite: l.IsFavorite,
+		}
+	})
+}
+
+func (a *UnifiedAPI) ListComments(ctx context.Context) ([]Comment, error) {
+	return a.listSyncComments(ctx, a.baseUrl+"/api/v1/sync")
+}
+
+// ListCompletedTasks reads tasks completed since the given time. The API limits a request to
+// a three month window, so longer ranges are read window by window.
+func (a *UnifiedAPI) ListCompletedTasks(ctx context.Context, since time.Time) ([]CompletedTask, error) {
+	const window = 89 * 24 * time.Hour
+
+	completedTasks := make([]CompletedTask, 0)
+	now := time.Now().UTC()
+	for start := since.UTC(); start.Before(now); start = start.Add(window) {
+		end := start.Add(window)
+		if end.After(now) {
+			end = now
+		}
+
+		query := url.Values{}
+		query.Set("since", start.Format(time.RFC3339))
+		query.Set("until", end.Format(time.RFC3339))
+		tasks, err := listAllConverted(ctx, &a.httpAPI, a.baseUrl+"/api/v1/tasks/completed/by_completion_date?"+query.Encode(), unifiedTask.toCompletedTask)
+		if err != nil {
+			return nil, err
+		}
+		completedTasks = append(completedTasks, tasks...)
+	}
+
+	return completedTasks, nil
+}
+
+func (a *UnifiedAPI) ListCollaborators(ctx context.Context) ([]Collaborator, error) {
+	return a.listSyncCollaborators(ctx, a.baseUrl+"/api/v1/sync")
+}
+
+func (a *UnifiedAPI) GetCurrentUser(ctx context.Context) (*User, error) {
+	return a.getSyncUser(ctx, a.baseUrl+"/api/v1/sync")
+}
+
+func (a *UnifiedAPI) ApplyCommands(ctx context.Context, commands []Command) (*SyncResponse, error) {
+	return a.applyCommands(ctx, a.baseUrl+"/api/v1/sync", commands)
+}
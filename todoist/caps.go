@@ -0,0 +1,118 @@
+package todoist
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+)
+
+// topProjectsCount is how many contributing projects are listed for a cap that was exceeded.
+const topProjectsCount = 3
+
+// ProjectTaskCount is the number of @next_action tasks directly in a project.
+type ProjectTaskCount struct {
+	ProjectName string `json:"projectName"`
+	Path        string `json:"path"`
+	TasksCount  int    `json:"tasksCount"`
+	URL         string `json:"url"`
+}
+
+// filterCaps returns a row for the global cap and for every area cap that is exceeded.
+// Rows list the projects contributing the most @next_action tasks in TopProjects.
+func (t *Client) filterCaps(tree *ProjectTree, nextActionTasks map[string][]Task, config NextActionCheckConfig) []IncorrectProjectSchema {
+	rows := make([]IncorrectProjectSchema, 0)
+	filterLabel := "next_action"
+
+	if config.GlobalLimit > 0 {
+		nodes := make([]*ProjectNode, 0, len(tree.ByID))
+		tree.Walk(func(node *ProjectNode) {
+			nodes = append(nodes, node)
+		})
+
+		tasksCount, topProjects := countCapTasks(nodes, nextActionTasks)
+		if tasksCount > config.GlobalLimit {
+			rows = append(rows, IncorrectProjectSchema{
+				ProjectName: "all projects",
+				TasksCount:  tasksCount,
+				URL:         t.getTasksURL("", &filterLabel),
+				Limit:       config.GlobalLimit,
+				TopProjects: topProjects,
+				Description: "More active tasks across all projects than allowed",
+			})
+		}
+	}
+
+	tree.Walk(func(node *ProjectNode) {
+		limit, ok := config.areaLimitFor(node)
+		if !ok {
+			return
+		}
+
+		tasksCount, topProjects := countCapTasks(tree.Subtree(node.ID), nextActionTasks)
+		if tasksCount > limit {
+			rows = append(rows, IncorrectProjectSchema{
+				ProjectName: node.Name,
+				Path:        node.Path,
+				Depth:       node.Depth,
+				TasksCount:  tasksCount,
+				URL:         t.getTasksURL("##"+node.Name, &filterLabel),
+				Limit:       limit,
+				TopProjects: topProjects,
+				Description: "Area has more active tasks than allowed",
+			})
+		}
+	})
+
+	return rows
+}
+
+func (c NextActionCheckConfig) areaLimitFor(node *ProjectNode) (limit int, ok bool) {
+	if limit, ok := c.AreaLimits[node.Path]; ok {
+		return limit, true
+	}
+	limit, ok = c.AreaLimits[node.Name]
+	return limit, ok
+}
+
+// countCapTasks counts @next_action tasks in nodes and returns the projects with the most of them.
+func countCapTasks(nodes []*ProjectNode, nextActionTasks map[string][]Task) (int, []ProjectTaskCount) {
+	total := 0
+	counts := make([]ProjectTaskCount, 0)
+	for _, node := range nodes {
+		tasksCount := len(nextActionTasks[node.ID])
+		if tasksCount == 0 {
+			continue
+		}
+		total += tasksCount
+		counts = append(counts, ProjectTaskCount{
+			ProjectName: node.Name,
+			Path:        node.Path,
+			TasksCount:  tasksCount,
+			URL:         node.Url,
+		})
+	}
+
+	slices.SortStableFunc(counts, func(a, b ProjectTaskCount) int {
+		return cmp.Compare(b.TasksCount, a.TasksCount)
+	})
+	return total, counts[:min(len(counts), topProjectsCount)]
+}
+
+// writeCapRows writes exceeded caps as "total/allowed" followed by their top projects.
+func writeCapRows(builder *strings.Builder, rows []IncorrectProjectSchema) {
+	const indent = "    "
+
+	for _, row := range rows {
+		name := row.ProjectName
+		if row.Path != "" {
+			name = row.Path
+		}
+		builder.WriteString(fmt.Sprintf("%d/%d - [%s](%s)\n", row.TasksCount, row.Limit, telegram.EscapeText(name), row.URL))
+		for _, p := range row.TopProjects {
+			builder.WriteString(fmt.Sprintf("%s%d - [%s](%s)\n", indent, p.TasksCount, telegram.EscapeText(p.Path), p.URL))
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package todoist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrettyOutputEscapesCapRows(t *testing.T) {
+	client := NewClientWithAPI(&MemoryAPI{})
+	rows := []IncorrectProjectSchema{
+		{
+			ProjectName: "Work [2024]",
+			Path:        "Areas/Work [2024]",
+			TasksCount:  5,
+			Limit:       3,
+			URL:         "https://todoist.com/app/search/work",
+			TopProjects: []ProjectTaskCount{
+				{ProjectName: "Q1 (draft)", Path: "Areas/Work [2024]/Q1 (draft)", TasksCount: 4, URL: "https://todoist.com/showProject?id=1"},
+			},
+		},
+	}
+
+	output := client.PrettyOutput(rows, nil)
+
+	for _, want := range []string{
+		`5/3 - [Areas/Work \[2024\]](https://todoist.com/app/search/work)`,
+		`    4 - [Areas/Work \[2024\]/Q1 \(draft\)](https://todoist.com/showProject?id=1)`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package todoist
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+)
+
+// AssignedTaskSchema is a report row for a task assigned in a shared project.
+type AssignedTaskSchema struct {
+	TaskID      string `json:"taskID"`
+	Content     string `json:"content"`
+	ProjectName string `json:"projectName"`
+	Path        string `json:"path,omitempty"`
+	// Person is the assignee of a delegated task or the assigner of a task assigned to the user.
+	Person      string `json:"person"`
+	AgeDays     int    `json:"ageDays"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+// AssigneeTasks groups delegated tasks of one person, oldest first.
+type AssigneeTasks struct {
+	Person string               `json:"person"`
+	Tasks  []AssignedTaskSchema `json:"tasks"`
+}
+
+func (t *Client) ListCollaborators(ctx context.Context) ([]Collaborator, error) {
+	return t.api.ListCollaborators(ctx)
+}
+
+func (t *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	return t.api.GetCurrentUser(ctx)
+}
+
+// GetDelegatedTasks lists open tasks the user assigned to someone else, grouped by assignee.
+// Age is counted in calendar days from task creation, as the API does not expose when a task was assigned.
+func (t *Client) GetDelegatedTasks() ([]AssigneeTasks, error) {
+	ctx := context.Background()
+	user, err := t.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collaborators, err := t.ListCollaborators(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := NewProjectTree(t.getProjectList())
+	return delegatedTasks(tree, t.getTasks(), collaborators, user.ID, t.Now()), nil
+}
+
+// GetTasksAssignedToMe lists open tasks other people assigned to the user, oldest first.
+func (t *Client) GetTasksAssignedToMe() ([]AssignedTaskSchema, error) {
+	ctx := context.Background()
+	user, err := t.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collaborators, err := t.ListCollaborators(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := NewProjectTree(t.getProjectList())
+	return tasksAssignedTo(tree, t.getTasks(), collaborators, user.ID, t.Now()), nil
+}
+
+func delegatedTasks(tree *ProjectTree, tasks []Task, collaborators []Collaborator, userID string, now time.Time) []AssigneeTasks {
+	byAssignee := map[string][]AssignedTaskSchema{}
+	for _, task := range tasks {
+		if task.AssigneeID == "" || task.AssigneeID == userID || task.AssignerID != userID {
+			continue
+		}
+
+		person := collaboratorName(collaborators, task.AssigneeID)
+		row := assignedTaskRow(tree, task, person, now)
+		row.Description = fmt.Sprintf("delegated to %s %d days ago", person, row.AgeDays)
+		byAssignee[person] = append(byAssignee[person], row)
+	}
+
+	groups := make([]AssigneeTasks, 0, len(byAssignee))
+	for person, rows := range byAssignee {
+		sortOldestFirst(rows)
+		groups = append(groups, AssigneeTasks{Person: person, Tasks: rows})
+	}
+	slices.SortFunc(groups, func(a, b AssigneeTasks) int {
+		return cmp.Compare(a.Person, b.Person)
+	})
+	return groups
+}
+
+func tasksAssignedTo(tree *ProjectTree, tasks []Task, collaborators []Collaborator, userID string, now time.Time) []AssignedTaskSchema {
+	rows := make([]AssignedTaskSchema, 0)
+	for _, task := range tasks {
+		if task.AssigneeID != userID || task.AssignerID == "" || task.AssignerID == userID {
+			continue
+		}
+
+		person := collaboratorName(collaborators, task.AssignerID)
+		row := assignedTaskRow(tree, task, person, now)
+		row.Description = fmt.Sprintf("assigned by %s %d days ago", person, row.AgeDays)
+		rows = append(rows, row)
+	}
+
+	sortOldestFirst(rows)
+	return rows
+}
+
+func assignedTaskRow(tree *ProjectTree, task Task, person string, now time.Time) AssignedTaskSchema {
+	row := AssignedTaskSchema{
+		TaskID:  task.ID,
+		Content: task.Content,
+		Person:  person,
+		AgeDays: calendarDaysBetween(task.CreatedAt.Time, now),
+		URL:     fmt.Sprintf("https://todoist.com/showTask?id=%s", task.ID),
+	}
+	if node, ok := tree.ByID[task.ProjectID]; ok {
+		row.ProjectName = node.Name
+		row.Path = node.Path
+	}
+	return row
+}
+
+func sortOldestFirst(rows []AssignedTaskSchema) {
+	slices.SortStableFunc(rows, func(a, b AssignedTaskSchema) int {
+		return cmp.Compare(b.AgeDays, a.AgeDays)
+	})
+}
+
+func collaboratorName(collaborators []Collaborator, id string) string {
+	for _, c := range collaborators {
+		if c.ID == id {
+			return c.Name
+		}
+	}
+	return id
+}
+
+// PrettyDelegatedOutput renders the delegated tasks of one person as a Telegram digest.
+func (t *Client) PrettyDelegatedOutput(group AssigneeTasks) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("waiting for %s on %d tasks:\n", telegram.EscapeText(group.Person), len(group.Tasks)))
+	writeAssignedTaskRows(&builder, group.Tasks, false)
+
+	return builder.String()
+}
+
+// PrettyAssignedToMeOutput renders tasks assigned to the user as a Telegram digest.
+func (t *Client) PrettyAssignedToMeOutput(rows []AssignedTaskSchema) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("%d tasks assigned to you:\n", len(rows)))
+	writeAssignedTaskRows(&builder, rows, true)
+
+	return builder.String()
+}
+
+func writeAssignedTaskRows(builder *strings.Builder, rows []AssignedTaskSchema, withPerson bool) {
+	for _, row := range rows {
+		builder.WriteString(fmt.Sprintf(
+			"%dd - [%s](%s) in %s",
+			row.AgeDays,
+			telegram.EscapeText(row.Content),
+			row.URL,
+			telegram.EscapeText(row.ProjectName),
+		))
+		if withPerson {
+			builder.WriteString(fmt.Sprintf(" from %s", telegram.EscapeText(row.Person)))
+		}
+		builder.WriteString("\n")
+	}
+}
@@ -0,0 +1,157 @@
+package todoist
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+)
+
+// DormantProjectsConfig configures GetDormantProjects.
+type DormantProjectsConfig struct {
+	// DormantAfterDays is for how many calendar days a project must have no added or completed tasks.
+	DormantAfterDays int
+	// Allowlist lists projects that are never proposed for archiving, matched like
+	// NextActionCheckConfig.ExcludeFromZeroProjectsList, which is usually passed here.
+	Allowlist []string
+	// KeptProjectIDs lists projects kept with the Keep button, which are not proposed again.
+	KeptProjectIDs []string
+	// FirstSeen maps project IDs to when the check first saw them. It is the age floor of projects,
+	// so ones without any known activity are only proposed DormantAfterDays after they were first seen.
+	// GetDormantProjects adds projects missing from it with the current time. With a nil map nothing is recorded,
+	// so projects without known activity are never proposed.
+	FirstSeen map[string]time.Time
+}
+
+// DormantProject is a project whose whole subtree has no open tasks other than @someday_maybe ones.
+type DormantProject struct {
+	ProjectID      string    `json:"projectID"`
+	ProjectName    string    `json:"projectName"`
+	Path           string    `json:"path"`
+	SomedayTasks   int       `json:"somedayTasks"`
+	LastActivityAt time.Time `json:"lastActivityAt,omitempty"`
+	URL            string    `json:"url"`
+}
+
+// GetDormantProjects lists projects that can be archived. Children of a dormant project are not listed separately,
+// as archiving a project archives its subprojects.
+// Todoist does not report when a project was created, so config.FirstSeen stands in for it.
+func (t *Client) GetDormantProjects(config DormantProjectsConfig) []DormantProject {
+	now := t.Now()
+	tree := NewProjectTree(t.getProjectList())
+	tasks := t.getTasks()
+	completedTasks := t.getCompletedTasks(t.DaysAgo(config.DormantAfterDays))
+
+	return findDormantProjects(tree, tasks, completedTasks, config, now)
+}
+
+func findDormantProjects(tree *ProjectTree, tasks []Task, completedTasks []CompletedTask, config DormantProjectsConfig, now time.Time) []DormantProject {
+	activeProjectIDs := map[string]bool{}
+	somedayTasks := map[string]int{}
+	lastActivity := map[string]time.Time{}
+	for _, task := range tasks {
+		if slices.Contains(task.Labels, "someday_maybe") {
+			somedayTasks[task.ProjectID]++
+		} else {
+			activeProjectIDs[task.ProjectID] = true
+		}
+		if task.CreatedAt.After(lastActivity[task.ProjectID]) {
+			lastActivity[task.ProjectID] = task.CreatedAt.Time
+		}
+	}
+	for _, task := range completedTasks {
+		if task.CompletedAt.After(lastActivity[task.ProjectID]) {
+			lastActivity[task.ProjectID] = task.CompletedAt.Time
+		}
+	}
+
+	if config.FirstSeen == nil {
+		config.FirstSeen = map[string]time.Time{}
+	}
+	for id := range tree.ByID {
+		if _, ok := config.FirstSeen[id]; !ok {
+			config.FirstSeen[id] = now
+		}
+	}
+
+	excluded := func(node *ProjectNode) bool {
+		return node.IsInboxProject || node.MatchesAny(config.Allowlist) || slices.Contains(config.KeptProjectIDs, node.ID)
+	}
+
+	dormantProjects := make([]DormantProject, 0)
+	proposedPaths := make([]string, 0)
+	tree.Walk(func(node *ProjectNode) {
+		if excluded(node) {
+			return
+		}
+		for _, path := range proposedPaths {
+			if strings.HasPrefix(node.Path, path+"/") {
+				return
+			}
+		}
+
+		dormant := DormantProject{
+			ProjectID:   node.ID,
+			ProjectName: node.Name,
+			Path:        node.Path,
+			URL:         node.Url,
+		}
+		// inactiveSince is the last activity, but no earlier than when the youngest project of the subtree was first seen
+		var inactiveSince time.Time
+		for _, n := range tree.Subtree(node.ID) {
+			if activeProjectIDs[n.ID] || excluded(n) {
+				return
+			}
+			dormant.SomedayTasks += somedayTasks[n.ID]
+			if lastActivity[n.ID].After(dormant.LastActivityAt) {
+				dormant.LastActivityAt = lastActivity[n.ID]
+			}
+			inactiveSince = latest(inactiveSince, lastActivity[n.ID], config.FirstSeen[n.ID])
+		}
+		if calendarDaysBetween(inactiveSince, now) < config.DormantAfterDays {
+			return
+		}
+
+		dormantProjects = append(dormantProjects, dormant)
+		proposedPaths = append(proposedPaths, node.Path)
+	})
+
+	return dormantProjects
+}
+
+func latest(times ...time.Time) time.Time {
+	var result time.Time
+	for _, t := range times {
+		if t.After(result) {
+			result = t
+		}
+	}
+	return result
+}
+
+// ArchiveProject archives a project together with its subprojects.
+func (t *Client) ArchiveProject(projectID string) error {
+	_, err := t.doSyncCommands([]Command{
+		newCommand("project_archive", map[string]any{"id": projectID}),
+	})
+	return err
+}
+
+// PrettyDormantProjectOutput renders the archive proposal for one project.
+func (t *Client) PrettyDormantProjectOutput(project DormantProject) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("archive [%s](%s)?\n", telegram.EscapeText(project.Path), project.URL))
+	if project.LastActivityAt.IsZero() {
+		builder.WriteString("no recent activity\n")
+	} else {
+		builder.WriteString(fmt.Sprintf("last activity on %s\n", project.LastActivityAt.Format(time.DateOnly)))
+	}
+	if project.SomedayTasks > 0 {
+		builder.WriteString(fmt.Sprintf("%d @someday_maybe tasks will be archived too\n", project.SomedayTasks))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,125 @@
+package todoist
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFindDormantProjects(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	longAgo := now.AddDate(0, -3, 0)
+	recently := now.AddDate(0, 0, -5)
+
+	projects := []Project{
+		{ID: "inbox", Name: "Inbox", IsInboxProject: true},
+		{ID: "old", Name: "Old"},
+		{ID: "old-child", Name: "Child", ParentID: "old"},
+		{ID: "someday", Name: "Someday"},
+		{ID: "areas", Name: "Areas"},
+		{ID: "active", Name: "Active", ParentID: "areas"},
+		{ID: "done", Name: "Done", ParentID: "areas"},
+		{ID: "fresh", Name: "Fresh"},
+		{ID: "new", Name: "New"},
+	}
+	tasks := []Task{
+		{ID: "1", ProjectID: "someday", Labels: []string{"someday_maybe"}, CreatedAt: TimeParser{longAgo}},
+		{ID: "2", ProjectID: "someday", Labels: []string{"someday_maybe"}, CreatedAt: TimeParser{longAgo}},
+		{ID: "3", ProjectID: "active", Labels: []string{"next_action"}, CreatedAt: TimeParser{longAgo}},
+	}
+	completedTasks := []CompletedTask{
+		{ProjectID: "fresh", CompletedAt: TimeParser{recently}},
+	}
+	// every project but New has been seen by earlier runs
+	firstSeen := func() map[string]time.Time {
+		seen := map[string]time.Time{}
+		for _, project := range projects {
+			if project.ID != "new" {
+				seen[project.ID] = longAgo
+			}
+		}
+		return seen
+	}
+
+	tests := []struct {
+		name   string
+		config DormantProjectsConfig
+		want   []string
+	}{
+		{
+			name: "nested projects are proposed with their parent only",
+			want: []string{"Old", "Someday", "Areas/Done"},
+		},
+		{
+			name:   "allowlisted projects are kept with their ancestors",
+			config: DormantProjectsConfig{Allowlist: []string{"Child", "Areas/**"}},
+			want:   []string{"Someday"},
+		},
+		{
+			name:   "kept projects are not proposed again",
+			config: DormantProjectsConfig{KeptProjectIDs: []string{"someday", "done"}},
+			want:   []string{"Old"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.DormantAfterDays = 30
+			tt.config.FirstSeen = firstSeen()
+
+			dormant := findDormantProjects(NewProjectTree(projects), tasks, completedTasks, tt.config, now)
+
+			got := make([]string, 0, len(dormant))
+			for _, project := range dormant {
+				got = append(got, project.Path)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("dormant = %v, want %v", got, tt.want)
+			}
+			if !tt.config.FirstSeen["new"].Equal(now) {
+				t.Errorf("New was first seen at %v, want %v", tt.config.FirstSeen["new"], now)
+			}
+		})
+	}
+}
+
+func TestFindDormantProjectsSomedayTasks(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	longAgo := now.AddDate(0, -3, 0)
+
+	projects := []Project{
+		{ID: "parent", Name: "Parent"},
+		{ID: "child", Name: "Child", ParentID: "parent"},
+	}
+	tasks := []Task{
+		{ID: "1", ProjectID: "parent", Labels: []string{"someday_maybe"}, CreatedAt: TimeParser{longAgo}},
+		{ID: "2", ProjectID: "child", Labels: []string{"someday_maybe"}, CreatedAt: TimeParser{longAgo.AddDate(0, 0, 1)}},
+	}
+	config := DormantProjectsConfig{
+		DormantAfterDays: 30,
+		FirstSeen:        map[string]time.Time{"parent": longAgo, "child": longAgo},
+	}
+
+	dormant := findDormantProjects(NewProjectTree(projects), tasks, nil, config, now)
+
+	if len(dormant) != 1 {
+		t.Fatalf("got %d dormant projects, want the parent only", len(dormant))
+	}
+	if dormant[0].SomedayTasks != 2 {
+		t.Errorf("someday tasks = %d, want the subtree's 2", dormant[0].SomedayTasks)
+	}
+	if !dormant[0].LastActivityAt.Equal(longAgo.AddDate(0, 0, 1)) {
+		t.Errorf("last activity = %v, want the child's task", dormant[0].LastActivityAt)
+	}
+}
+
+func TestFindDormantProjectsWithoutFirstSeen(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	projects := []Project{{ID: "empty", Name: "Empty"}}
+
+	dormant := findDormantProjects(NewProjectTree(projects), nil, nil, DormantProjectsConfig{DormantAfterDays: 1}, now)
+
+	if len(dormant) != 0 {
+		t.Errorf("proposed %v without any evidence of the project's age", dormant)
+	}
+}
@@ -0,0 +1,192 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrNotFound is returned when the requested object does not exist or is not accessible.
+var ErrNotFound = errors.New("todoist object not found")
+
+// httpAPI holds what the HTTP backends share: authentication, the HTTP client and pagination settings.
+type httpAPI struct {
+	apiToken   string
+	httpClient *http.Client
+	pageSize   int
+	baseUrl    string
+}
+
+func (h *httpAPI) doGetRequest(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.do(req)
+}
+
+func (h *httpAPI) doPostFormRequest(ctx context.Context, url string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	return h.do(req)
+}
+
+func (h *httpAPI) do(req *http.Request) ([]byte, error) {
+	headerKey, headerValue := "Authorization", fmt.Sprintf("Bearer %s", h.apiToken)
+	req.Header.Add(headerKey, headerValue)
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w, url=%s", ErrNotFound, req.URL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("todoist request failed, url=%s, code=%d, body=%v", req.URL, resp.StatusCode, string(b))
+	}
+
+	return b, nil
+}
+
+// getJSON reads a single object.
+func getJSON[T any](ctx context.Context, h *httpAPI, url string) (*T, error) {
+	b, err := h.doGetRequest(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var v T
+	err = json.Unmarshal(b, &v)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// applyCommands posts commands to a Sync endpoint and checks the status of every command.
+func (h *httpAPI) applyCommands(ctx context.Context, syncUrl string, commands []Command) (*SyncResponse, error) {
+	if len(commands) > syncCommandsLimit {
+		return nil, fmt.Errorf("too many sync commands: %d, limit is %d", len(commands), syncCommandsLimit)
+	}
+
+	b, err := json.Marshal(commands)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("commands", string(b))
+	resp, err := h.doPostFormRequest(ctx, syncUrl, form)
+	if err != nil {
+		return nil, err
+	}
+
+	var syncResponse SyncResponse
+	err = json.Unmarshal(resp, &syncResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, command := range commands {
+		status, ok := syncResponse.SyncStatus[command.Uuid]
+		if !ok {
+			return nil, fmt.Errorf("missing sync status for command type=%s uuid=%s", command.Type, command.Uuid)
+		}
+		if string(status) != `"ok"` {
+			return nil, fmt.Errorf("sync command type=%s uuid=%s failed: %s", command.Type, command.Uuid, string(status))
+		}
+	}
+
+	return &syncResponse, nil
+}
+
+// readSyncResources reads resources such as notes with a full sync.
+func (h *httpAPI) readSyncResources(ctx context.Context, syncUrl string, resourceTypes []string, v any) error {
+	b, err := json.Marshal(resourceTypes)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("sync_token", "*")
+	form.Set("resource_types", string(b))
+	resp, err := h.doPostFormRequest(ctx, syncUrl, form)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resp, v)
+}
+
+type syncNote struct {
+	Comment
+	IsDeleted bool `json:"is_deleted"`
+}
+
+func (h *httpAPI) listSyncComments(ctx context.Context, syncUrl string) ([]Comment, error) {
+	var resp struct {
+		Notes        []syncNote `json:"notes"`
+		ProjectNotes []syncNote `json:"project_notes"`
+	}
+	err := h.readSyncResources(ctx, syncUrl, []string{"notes", "project_notes"}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]Comment, 0, len(resp.Notes)+len(resp.ProjectNotes))
+	for _, n := range append(resp.Notes, resp.ProjectNotes...) {
+		if !n.IsDeleted {
+			comments = append(comments, n.Comment)
+		}
+	}
+	return comments, nil
+}
+
+func (h *httpAPI) listSyncCollaborators(ctx context.Context, syncUrl string) ([]Collaborator, error) {
+	var resp struct {
+		Collaborators []Collaborator `json:"collaborators"`
+	}
+	err := h.readSyncResources(ctx, syncUrl, []string{"collaborators"}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Collaborators, nil
+}
+
+func (h *httpAPI) getSyncUser(ctx context.Context, syncUrl string) (*User, error) {
+	var resp struct {
+		User struct {
+			User
+			TzInfo struct {
+				Timezone string `json:"timezone"`
+			} `json:"tz_info"`
+		} `json:"user"`
+	}
+	err := h.readSyncResources(ctx, syncUrl, []string{"user"}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	user := resp.User.User
+	user.Timezone = resp.User.TzInfo.Timezone
+	return &user, nil
+}
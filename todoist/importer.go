@@ -0,0 +1,460 @@
+package todoist
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type ImportFormat string
+
+const (
+	ImportFormatMarkdown ImportFormat = "markdown"
+	ImportFormatCSV      ImportFormat = "csv"
+	ImportFormatText     ImportFormat = "text"
+)
+
+// ImportedTask is a task read from an import file, not yet created in Todoist.
+type ImportedTask struct {
+	Content     string `json:"content"`
+	Description string `json:"description,omitempty"`
+	// Project is a project name or path from a `#project` token. Empty means the default project.
+	Project string `json:"project,omitempty"`
+	// Section is created in the task's project if it does not exist.
+	Section  string         `json:"section,omitempty"`
+	Labels   []string       `json:"labels,omitempty"`
+	Priority Priority       `json:"priority,omitempty"`
+	Due      string         `json:"due,omitempty"`
+	Subtasks []ImportedTask `json:"subtasks,omitempty"`
+}
+
+type ImportResult struct {
+	Tasks    []ImportedTask `json:"tasks"`
+	Commands []Command      `json:"commands"`
+}
+
+func ParseImport(r io.Reader, format ImportFormat) ([]ImportedTask, error) {
+	switch format {
+	case ImportFormatMarkdown:
+		return parseMarkdownImport(r)
+	case ImportFormatCSV:
+		return parseCSVImport(r)
+	case ImportFormatText:
+		return parseTextImport(r)
+	default:
+		return nil, fmt.Errorf("unknown import format `%s`", format)
+	}
+}
+
+var (
+	markdownHeadingRegexp = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+	markdownItemRegexp    = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(?:\[( |x|X)\]\s+)?(.+)$`)
+	priorityTokenRegexp   = regexp.MustCompile(`^[pP][1-4]$`)
+)
+
+// parseMarkdownImport reads checklists and bullet lists. Nested items become subtasks,
+// headings become sections and checked items are skipped.
+func parseMarkdownImport(r io.Reader) ([]ImportedTask, error) {
+	type level struct {
+		indent int
+		task   *ImportedTask
+	}
+
+	var roots []*ImportedTask
+	var stack []level
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.ReplaceAll(scanner.Text(), "\t", "    ")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		if match := markdownHeadingRegexp.FindStringSubmatch(trimmed); match != nil {
+			section = strings.TrimSpace(match[1])
+			stack = nil
+			continue
+		}
+
+		match := markdownItemRegexp.FindStringSubmatch(trimmed)
+		if match == nil {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		if strings.EqualFold(match[1], "x") {
+			// completed items are skipped with their subtasks
+			stack = append(stack, level{indent: indent, task: &ImportedTask{}})
+			continue
+		}
+
+		task, err := parseInlineTokens(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		if len(stack) == 0 {
+			task.Section = section
+			roots = append(roots, &task)
+			stack = append(stack, level{indent: indent, task: roots[len(roots)-1]})
+			continue
+		}
+
+		parent := stack[len(stack)-1].task
+		parent.Subtasks = append(parent.Subtasks, task)
+		stack = append(stack, level{indent: indent, task: &parent.Subtasks[len(parent.Subtasks)-1]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return dereferenceTasks(roots), nil
+}
+
+// dereferenceTasks copies tasks after parsing, since subtasks are appended through pointers.
+func dereferenceTasks(tasks []*ImportedTask) []ImportedTask {
+	result := make([]ImportedTask, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, *task)
+	}
+	return result
+}
+
+// parseInlineTokens extracts `#project`, `@label`, `p1`..`p4` and `due:<date>` tokens from a task line.
+// Underscores in project and due tokens stand for spaces, e.g. `#Side_Projects` or `due:next_monday`.
+// Numeric `#123` is an issue reference and stays in the content, like in Toggl time entry input.
+func parseInlineTokens(text string) (ImportedTask, error) {
+	var task ImportedTask
+	words := make([]string, 0)
+
+	for _, word := range strings.Fields(text) {
+		switch {
+		case len(word) > 1 && strings.HasPrefix(word, "#") && !isIssueReference(word):
+			task.Project = strings.ReplaceAll(word[1:], "_", " ")
+		case len(word) > 1 && strings.HasPrefix(word, "@"):
+			task.Labels = append(task.Labels, word[1:])
+		case priorityTokenRegexp.MatchString(word):
+			priority, err := ParsePriority(word)
+			if err != nil {
+				return task, err
+			}
+			task.Priority = priority
+		case strings.HasPrefix(strings.ToLower(word), "due:") && len(word) > len("due:"):
+			task.Due = strings.ReplaceAll(word[len("due:"):], "_", " ")
+		default:
+			words = append(words, word)
+		}
+	}
+
+	task.Content = strings.Join(words, " ")
+	if task.Content == "" {
+		return task, fmt.Errorf("empty task in `%s`", text)
+	}
+	return task, nil
+}
+
+func isIssueReference(word string) bool {
+	_, err := strconv.ParseUint(strings.TrimPrefix(word, "#"), 10, 64)
+	return strings.HasPrefix(word, "#") && err == nil
+}
+
+// parseCSVImport reads Todoist's CSV template format (TYPE, CONTENT, DESCRIPTION, PRIORITY, INDENT, ..., DATE).
+// CSV priorities follow the Todoist UI, where 1 is the most urgent.
+func parseCSVImport(r io.Reader) ([]ImportedTask, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToUpper(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"TYPE", "CONTENT"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv is missing the %s column", required)
+		}
+	}
+	column := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var roots []*ImportedTask
+	var stack []*ImportedTask
+	section := ""
+
+	for rowNumber := 2; ; rowNumber++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch strings.ToLower(column(record, "TYPE")) {
+		case "section":
+			section = column(record, "CONTENT")
+			stack = nil
+			continue
+		case "task":
+		default:
+			// notes and empty rows are not imported
+			continue
+		}
+
+		task, err := parseInlineTokens(column(record, "CONTENT"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNumber, err)
+		}
+		task.Description = column(record, "DESCRIPTION")
+		task.Due = column(record, "DATE")
+		if priority := column(record, "PRIORITY"); priority != "" {
+			task.Priority, err = ParsePriority(priority)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", rowNumber, err)
+			}
+		}
+
+		indent := 1
+		if s := column(record, "INDENT"); s != "" {
+			indent, err = strconv.Atoi(s)
+			if err != nil || indent < 1 {
+				return nil, fmt.Errorf("row %d: invalid indent `%s`", rowNumber, s)
+			}
+		}
+		if indent > len(stack)+1 {
+			return nil, fmt.Errorf("row %d: indent %d has no parent task", rowNumber, indent)
+		}
+		stack = stack[:indent-1]
+
+		if len(stack) == 0 {
+			task.Section = section
+			roots = append(roots, &task)
+			stack = append(stack, roots[len(roots)-1])
+			continue
+		}
+
+		parent := stack[len(stack)-1]
+		parent.Subtasks = append(parent.Subtasks, task)
+		stack = append(stack, &parent.Subtasks[len(parent.Subtasks)-1])
+	}
+
+	return dereferenceTasks(roots), nil
+}
+
+// parseTextImport reads one task per non-empty line without token parsing.
+func parseTextImport(r io.Reader) ([]ImportedTask, error) {
+	tasks := make([]ImportedTask, 0)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			tasks = append(tasks, ImportedTask{Content: line})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// ImportTasks creates tasks with batched Sync API requests. Each top-level task is sent
+// in the same request as its subtasks and any new section, so temp IDs always resolve.
+func (t *Client) ImportTasks(tasks []ImportedTask, defaultProjectName string, dryRun bool) (*ImportResult, error) {
+	projects := t.getProjectList()
+	tree := NewProjectTree(projects)
+	tree.SetSections(t.getSectionList())
+
+	defaultProject, ok := tree.FindByNameOrPath(defaultProjectName)
+	if !ok {
+		return nil, fmt.Errorf("did not find `%s` project", defaultProjectName)
+	}
+
+	sectionIDs := map[string]string{}
+	for _, node := range tree.ByID {
+		for _, section := range node.Sections {
+			sectionIDs[node.ID+"/"+section.Name] = section.ID
+		}
+	}
+
+	groups := make([][]Command, 0, len(tasks))
+	// newSectionGroups maps sections created by this import to the group that creates them,
+	// so tasks in a new section are sent in the same request as its section_add.
+	newSectionGroups := map[string]int{}
+	for _, task := range tasks {
+		project := defaultProject
+		if task.Project != "" {
+			project, ok = tree.FindByNameOrPath(task.Project)
+			if !ok {
+				return nil, fmt.Errorf("did not find `%s` project for task `%s`", task.Project, task.Content)
+			}
+		}
+
+		if task.Section == "" {
+			groups = append(groups, importedTaskCommands(task, project.ID, "", ""))
+			continue
+		}
+
+		key := project.ID + "/" + task.Section
+		if sectionID, ok := sectionIDs[key]; ok {
+			commands := importedTaskCommands(task, project.ID, sectionID, "")
+			if i, ok := newSectionGroups[key]; ok {
+				groups[i] = append(groups[i], commands...)
+			} else {
+				groups = append(groups, commands)
+			}
+			continue
+		}
+
+		sectionCommand := newTempIDCommand("section_add", map[string]any{
+			"name":       task.Section,
+			"project_id": project.ID,
+		})
+		sectionIDs[key] = sectionCommand.TempID
+		newSectionGroups[key] = len(groups)
+		group := append([]Command{sectionCommand}, importedTaskCommands(task, project.ID, sectionCommand.TempID, "")...)
+		groups = append(groups, group)
+	}
+
+	batches, err := batchCommandGroups(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	commands := make([]Command, 0)
+	for _, batch := range batches {
+		commands = append(commands, batch...)
+	}
+	result := &ImportResult{
+		Tasks:    tasks,
+		Commands: commands,
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	for _, batch := range batches {
+		_, err := t.doSyncCommands(batch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func importedTaskCommands(task ImportedTask, projectID, sectionID, parentID string) []Command {
+	args := map[string]any{
+		"content":    task.Content,
+		"project_id": projectID,
+	}
+	if sectionID != "" {
+		args["section_id"] = sectionID
+	}
+	if parentID != "" {
+		args["parent_id"] = parentID
+	}
+	if task.Description != "" {
+		args["description"] = task.Description
+	}
+	if len(task.Labels) > 0 {
+		args["labels"] = task.Labels
+	}
+	if task.Priority != 0 {
+		args["priority"] = task.Priority.API()
+	}
+	if task.Due != "" {
+		args["due"] = map[string]string{"string": task.Due}
+	}
+
+	command := newTempIDCommand("item_add", args)
+	commands := []Command{command}
+	for _, subtask := range task.Subtasks {
+		commands = append(commands, importedTaskCommands(subtask, projectID, sectionID, command.TempID)...)
+	}
+	return commands
+}
+
+// batchCommandGroups packs groups of commands into Sync API sized batches without splitting a group.
+func batchCommandGroups(groups [][]Command) ([][]Command, error) {
+	batches := make([][]Command, 0)
+	batch := make([]Command, 0, syncCommandsLimit)
+
+	for _, group := range groups {
+		if len(group) > syncCommandsLimit {
+			return nil, fmt.Errorf("tasks sharing a new section or parent need %d commands, limit is %d", len(group), syncCommandsLimit)
+		}
+		if len(batch)+len(group) > syncCommandsLimit {
+			batches = append(batches, batch)
+			batch = make([]Command, 0, syncCommandsLimit)
+		}
+		batch = append(batch, group...)
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// PrettyImportPreview renders tasks as an indented list for dry runs.
+func PrettyImportPreview(tasks []ImportedTask, defaultProjectName string) string {
+	builder := strings.Builder{}
+
+	var write func(tasks []ImportedTask, depth int)
+	write = func(tasks []ImportedTask, depth int) {
+		for _, task := range tasks {
+			builder.WriteString(strings.Repeat("  ", depth))
+			builder.WriteString(task.Content)
+
+			details := make([]string, 0)
+			if depth == 0 {
+				project := task.Project
+				if project == "" {
+					project = defaultProjectName
+				}
+				if task.Section != "" {
+					project += "/" + task.Section
+				}
+				details = append(details, "#"+project)
+			}
+			for _, label := range task.Labels {
+				details = append(details, "@"+label)
+			}
+			if task.Priority != 0 {
+				details = append(details, task.Priority.String())
+			}
+			if task.Due != "" {
+				details = append(details, "due "+task.Due)
+			}
+			if len(details) > 0 {
+				builder.WriteString(" [" + strings.Join(details, " ") + "]")
+			}
+			builder.WriteString("\n")
+
+			write(task.Subtasks, depth+1)
+		}
+	}
+	write(tasks, 0)
+
+	return builder.String()
+}
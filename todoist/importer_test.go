@@ -0,0 +1,197 @@
+package todoist
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseImport(t *testing.T) {
+	tests := []struct {
+		name   string
+		format ImportFormat
+		input  string
+		want   []ImportedTask
+	}{
+		{
+			name:   "markdown nesting, sections and tokens",
+			format: ImportFormatMarkdown,
+			input: `- [ ] Plan trip #Side_Projects @next_action p1 due:next_monday
+  - [ ] Book flights
+    - Compare prices
+  - [x] Done already
+    - Skipped with its parent
+- [ ] Fix #123 in the importer
+
+## Errands
+1. Buy milk @errands
+* Call bank`,
+			want: []ImportedTask{
+				{
+					Content:  "Plan trip",
+					Project:  "Side Projects",
+					Labels:   []string{"next_action"},
+					Priority: P1,
+					Due:      "next monday",
+					Subtasks: []ImportedTask{
+						{Content: "Book flights", Subtasks: []ImportedTask{{Content: "Compare prices"}}},
+					},
+				},
+				{Content: "Fix #123 in the importer"},
+				{Content: "Buy milk", Section: "Errands", Labels: []string{"errands"}},
+				{Content: "Call bank", Section: "Errands"},
+			},
+		},
+		{
+			name:   "csv template",
+			format: ImportFormatCSV,
+			input: `TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE
+task,Write report @work,Quarterly numbers,1,1,,,tomorrow
+task,Collect data,,4,2,,,
+note,Ignored note,,,,,,
+section,Later,,,,,,
+task,Close #42,,,1,,,`,
+			want: []ImportedTask{
+				{
+					Content:     "Write report",
+					Description: "Quarterly numbers",
+					Labels:      []string{"work"},
+					Priority:    P1,
+					Due:         "tomorrow",
+					Subtasks:    []ImportedTask{{Content: "Collect data", Priority: P4}},
+				},
+				{Content: "Close #42", Section: "Later"},
+			},
+		},
+		{
+			name:   "plain text keeps tokens",
+			format: ImportFormatText,
+			input:  "Fix #123 @home p1\n\n  Water plants  \n",
+			want: []ImportedTask{
+				{Content: "Fix #123 @home p1"},
+				{Content: "Water plants"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseImport(strings.NewReader(tt.input), tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseImport =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImportErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format ImportFormat
+		input  string
+	}{
+		{name: "markdown task without content", format: ImportFormatMarkdown, input: "- [ ] @home p2"},
+		{name: "csv without content column", format: ImportFormatCSV, input: "TYPE,PRIORITY\ntask,1"},
+		{name: "csv indent without parent", format: ImportFormatCSV, input: "TYPE,CONTENT,INDENT\ntask,Orphan,2"},
+		{name: "unknown format", format: "org", input: "* TODO task"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseImport(strings.NewReader(tt.input), tt.format); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestBatchCommandGroups(t *testing.T) {
+	group := func(size int) []Command {
+		commands := make([]Command, size)
+		for i := range commands {
+			commands[i] = newCommand("item_add", nil)
+		}
+		return commands
+	}
+
+	batches, err := batchCommandGroups([][]Command{group(60), group(30), group(20), group(100), group(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sizes := make([]int, 0, len(batches))
+	for _, batch := range batches {
+		sizes = append(sizes, len(batch))
+	}
+	if want := []int{90, 20, 100, 1}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("batch sizes = %v, want %v", sizes, want)
+	}
+
+	if _, err := batchCommandGroups([][]Command{group(syncCommandsLimit + 1)}); err == nil {
+		t.Error("expected an error for a group over the limit")
+	}
+}
+
+func TestImportTasksLinksTempIDs(t *testing.T) {
+	api := &MemoryAPI{
+		Projects: []Project{{ID: "inbox", Name: "Inbox"}, {ID: "work", Name: "Work"}},
+		Sections: []Section{{ID: "existing", ProjectID: "work", Name: "Now"}},
+	}
+	client := NewClientWithAPI(api)
+
+	tasks := []ImportedTask{
+		{Content: "Parent", Project: "Work", Section: "Later", Subtasks: []ImportedTask{{Content: "Child"}}},
+		{Content: "Sibling", Project: "Work", Section: "Later"},
+		{Content: "Current", Project: "Work", Section: "Now"},
+		{Content: "Loose"},
+	}
+	result, err := client.ImportTasks(tasks, "Inbox", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Commands) != 6 {
+		t.Fatalf("got %d commands, want 6", len(result.Commands))
+	}
+	if len(api.Sections) != 2 {
+		t.Fatalf("got %d sections, want the new section created once", len(api.Sections))
+	}
+	later := api.Sections[1].ID
+
+	byContent := map[string]Task{}
+	for _, task := range api.Tasks {
+		byContent[task.Content] = task
+	}
+	want := map[string]Task{
+		"Parent":  {ProjectID: "work", SectionID: later},
+		"Child":   {ProjectID: "work", SectionID: later, ParentID: byContent["Parent"].ID},
+		"Sibling": {ProjectID: "work", SectionID: later},
+		"Current": {ProjectID: "work", SectionID: "existing"},
+		"Loose":   {ProjectID: "inbox"},
+	}
+	for content, w := range want {
+		got, ok := byContent[content]
+		if !ok {
+			t.Errorf("task %s was not created", content)
+			continue
+		}
+		if got.ProjectID != w.ProjectID || got.SectionID != w.SectionID || got.ParentID != w.ParentID {
+			t.Errorf("%s: project=%s section=%s parent=%s, want project=%s section=%s parent=%s",
+				content, got.ProjectID, got.SectionID, got.ParentID, w.ProjectID, w.SectionID, w.ParentID)
+		}
+	}
+}
+
+func TestImportTasksDryRun(t *testing.T) {
+	api := &MemoryAPI{Projects: []Project{{ID: "inbox", Name: "Inbox"}}}
+	client := NewClientWithAPI(api)
+
+	result, err := client.ImportTasks([]ImportedTask{{Content: "Task", Section: "New"}}, "Inbox", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Commands) != 2 || len(api.Tasks) != 0 || len(api.Sections) != 0 {
+		t.Errorf("dry run made %d commands, %d tasks, %d sections", len(result.Commands), len(api.Tasks), len(api.Sections))
+	}
+}
@@ -0,0 +1,164 @@
+package todoist
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+)
+
+// InboxMonitorConfig sets when Inbox reminders are sent.
+type InboxMonitorConfig struct {
+	// CountThreshold is the number of Inbox tasks that triggers a reminder.
+	CountThreshold int
+	// OldestAgeDays is the age in calendar days of the oldest Inbox task that triggers a reminder.
+	OldestAgeDays int
+	// ArchiveAfterDays and ProtectedPriority mirror the arguments of MoveInactiveTasks for the Inbox.
+	ArchiveAfterDays  int
+	ProtectedPriority Priority
+	// ReminderHour is the hour in the user's timezone at which daily reminders are sent.
+	// More frequent reminders are aligned to it.
+	ReminderHour int
+}
+
+var DefaultInboxMonitorConfig = InboxMonitorConfig{
+	CountThreshold:    10,
+	OldestAgeDays:     2,
+	ArchiveAfterDays:  3,
+	ProtectedPriority: DefaultProtectedPriority,
+	ReminderHour:      9,
+}
+
+// ParseInboxMonitorConfig returns DefaultInboxMonitorConfig with the protected priority, e.g. "p1", when it is set.
+// The archive run and the reminder should share the result, so the reminder lists exactly the tasks to be archived.
+func ParseInboxMonitorConfig(protectedPriority string) (InboxMonitorConfig, error) {
+	config := DefaultInboxMonitorConfig
+	if protectedPriority == "" {
+		return config, nil
+	}
+
+	priority, err := ParsePriority(protectedPriority)
+	if err != nil {
+		return config, err
+	}
+	config.ProtectedPriority = priority
+	return config, nil
+}
+
+// reminderIntervals maps escalation levels to hours between reminders.
+var reminderIntervals = map[int]int{
+	1: 24,
+	2: 4,
+	3: 1,
+}
+
+type InboxStatus struct {
+	Count int `json:"count"`
+	// OldestAgeDays counts calendar days since the oldest task was added, see calendarDaysBetween.
+	OldestAgeDays int         `json:"oldestAgeDays"`
+	Ages          []AgeBucket `json:"ages"`
+	// Level is 0 while under thresholds and grows to 3 as the Inbox reaches 3x a threshold.
+	Level int `json:"level"`
+	// ToBeArchived lists tasks that the next day's archive run will move out of the Inbox.
+	ToBeArchived []Task `json:"toBeArchived"`
+}
+
+func (t *Client) GetInboxStatus(config InboxMonitorConfig) InboxStatus {
+	projects := t.getProjectList()
+	inbox, ok := findInboxProject(projects)
+	if !ok {
+		log.Fatal("did not find Inbox project")
+	}
+
+	tasks := t.getProjectTasks(*inbox)
+	return t.computeInboxStatus(tasks, config, t.Now())
+}
+
+func findInboxProject(projects []Project) (*Project, bool) {
+	for _, p := range projects {
+		if p.IsInboxProject {
+			return &p, true
+		}
+	}
+	for _, p := range projects {
+		if p.Name == "Inbox" {
+			return &p, true
+		}
+	}
+	return nil, false
+}
+
+func (t *Client) computeInboxStatus(tasks []Task, config InboxMonitorConfig, now time.Time) InboxStatus {
+	status := InboxStatus{
+		Count:        len(tasks),
+		Ages:         make([]AgeBucket, len(ageBucketLimits)),
+		ToBeArchived: make([]Task, 0),
+	}
+
+	for i, limit := range ageBucketLimits {
+		status.Ages[i].Label = limit.label
+	}
+
+	for _, task := range tasks {
+		status.OldestAgeDays = max(status.OldestAgeDays, calendarDaysBetween(task.CreatedAt.Time, now))
+		age := now.Sub(task.CreatedAt.Time)
+		for i, limit := range ageBucketLimits {
+			if limit.upTo == 0 || age < limit.upTo {
+				status.Ages[i].Count++
+				break
+			}
+		}
+	}
+	ratio := 0.0
+	if config.CountThreshold > 0 {
+		ratio = max(ratio, float64(status.Count)/float64(config.CountThreshold))
+	}
+	if config.OldestAgeDays > 0 {
+		ratio = max(ratio, float64(status.OldestAgeDays)/float64(config.OldestAgeDays))
+	}
+	status.Level = min(int(ratio), 3)
+
+	archivedTomorrow := t.filterTasksOlderThanDays(tasks, config.ArchiveAfterDays-1, now)
+	status.ToBeArchived = t.filterByPriority(archivedTomorrow, config.ProtectedPriority)
+
+	return status
+}
+
+// ShouldRemind reports whether a reminder is due at the given time, which should be in the user's timezone.
+// It is meant to be called hourly: the higher the level, the more hours send a reminder.
+// Tasks about to be archived are announced at ReminderHour even while the Inbox is under thresholds.
+func (s InboxStatus) ShouldRemind(now time.Time, config InboxMonitorConfig) bool {
+	hoursSinceReminderHour := (now.Hour() - config.ReminderHour + 24) % 24
+	if hoursSinceReminderHour == 0 && len(s.ToBeArchived) > 0 {
+		return true
+	}
+
+	interval, ok := reminderIntervals[s.Level]
+	if !ok {
+		return false
+	}
+	return hoursSinceReminderHour%interval == 0
+}
+
+func (t *Client) PrettyInboxOutput(status InboxStatus) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Inbox has %d tasks, oldest is %d days old\n", status.Count, status.OldestAgeDays))
+	for _, bucket := range status.Ages {
+		if bucket.Count > 0 {
+			builder.WriteString(fmt.Sprintf("%s: %d\n", bucket.Label, bucket.Count))
+		}
+	}
+
+	if len(status.ToBeArchived) > 0 {
+		builder.WriteString("\n")
+		builder.WriteString("will be archived tomorrow:\n")
+		for _, task := range status.ToBeArchived {
+			builder.WriteString(fmt.Sprintf("%s %s\n", task.Priority, telegram.EscapeText(task.Content)))
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,50 @@
+package todoist
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInboxStatusShouldRemind(t *testing.T) {
+	config := DefaultInboxMonitorConfig
+	at := func(hour int) time.Time { return time.Date(2024, 3, 1, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		status InboxStatus
+		now    time.Time
+		want   bool
+	}{
+		{name: "under thresholds", status: InboxStatus{Level: 0}, now: at(9), want: false},
+		{name: "tasks to archive at reminder hour", status: InboxStatus{Level: 0, ToBeArchived: []Task{{ID: "1"}}}, now: at(9), want: true},
+		{name: "tasks to archive at other hours", status: InboxStatus{Level: 0, ToBeArchived: []Task{{ID: "1"}}}, now: at(10), want: false},
+		{name: "daily level at reminder hour", status: InboxStatus{Level: 1}, now: at(9), want: true},
+		{name: "daily level at other hours", status: InboxStatus{Level: 1}, now: at(13), want: false},
+		{name: "4 hourly level", status: InboxStatus{Level: 2}, now: at(13), want: true},
+		{name: "hourly level", status: InboxStatus{Level: 3}, now: at(2), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.ShouldRemind(tt.now, config); got != tt.want {
+				t.Errorf("ShouldRemind = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInboxMonitorConfig(t *testing.T) {
+	config, err := ParseInboxMonitorConfig("")
+	if err != nil || config != DefaultInboxMonitorConfig {
+		t.Errorf("empty priority = %+v, %v, want the default config", config, err)
+	}
+
+	config, err = ParseInboxMonitorConfig("p1")
+	if err != nil || config.ProtectedPriority != P1 {
+		t.Errorf("p1 = %+v, %v, want ProtectedPriority p1", config, err)
+	}
+
+	if _, err := ParseInboxMonitorConfig("urgent"); err == nil {
+		t.Error("expected an error for an unknown priority")
+	}
+}
@@ -0,0 +1,231 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// GTDLabels are the labels every task is expected to have one of.
+var GTDLabels = []RequiredLabel{
+	{Name: "next_action", Color: "red"},
+	{Name: "someday_maybe", Color: "grey"},
+	{Name: "waiting_for", Color: "orange"},
+	{Name: "reference", Color: "blue"},
+}
+
+// hasGTDLabel reports whether the task already has one of GTDLabels.
+func hasGTDLabel(task Task) bool {
+	return slices.ContainsFunc(GTDLabels, func(label RequiredLabel) bool {
+		return slices.Contains(task.Labels, label.Name)
+	})
+}
+
+type RequiredLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type LabelUsage struct {
+	Name string `json:"name"`
+	// Personal labels exist in the label list, shared labels only appear on tasks.
+	Personal bool `json:"personal"`
+	Count    int  `json:"count"`
+}
+
+// LabelChanges lists what a label operation did, or would do in a dry run.
+type LabelChanges struct {
+	Changes  []string  `json:"changes"`
+	Commands []Command `json:"commands"`
+}
+
+func (t *Client) ListLabels(ctx context.Context) ([]Label, error) {
+	return t.api.ListLabels(ctx)
+}
+
+func (t *Client) getLabelList() []Label {
+	labels, err := t.ListLabels(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return labels
+}
+
+// GetLabelUsage lists personal and shared labels with the number of open tasks using them, least used first.
+func (t *Client) GetLabelUsage() []LabelUsage {
+	return labelUsage(t.getLabelList(), t.getTasks())
+}
+
+func labelUsage(labels []Label, tasks []Task) []LabelUsage {
+	usage := map[string]*LabelUsage{}
+	for _, label := range labels {
+		usage[label.Name] = &LabelUsage{Name: label.Name, Personal: true}
+	}
+	for _, task := range tasks {
+		for _, name := range task.Labels {
+			if _, ok := usage[name]; !ok {
+				usage[name] = &LabelUsage{Name: name}
+			}
+			usage[name].Count++
+		}
+	}
+
+	result := make([]LabelUsage, 0, len(usage))
+	for _, u := range usage {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count < result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// EnsureLabels creates required labels that do not exist as personal labels yet.
+func (t *Client) EnsureLabels(required []RequiredLabel, dryRun bool) (*LabelChanges, error) {
+	labels := t.getLabelList()
+
+	changes := &LabelChanges{}
+	for _, r := range required {
+		exists := slices.ContainsFunc(labels, func(l Label) bool {
+			return l.Name == r.Name
+		})
+		if exists {
+			continue
+		}
+
+		args := map[string]any{"name": r.Name}
+		if r.Color != "" {
+			args["color"] = r.Color
+		}
+		changes.add(fmt.Sprintf("create @%s", r.Name), newCommand("label_add", args))
+	}
+
+	return changes, t.applyLabelChanges(changes, dryRun)
+}
+
+// RenameLabel renames a label on all tasks. Personal labels are renamed in place,
+// which Todoist applies to their tasks, shared labels are renamed with label_rename.
+func (t *Client) RenameLabel(oldName, newName string, dryRun bool) (*LabelChanges, error) {
+	labels := t.getLabelList()
+
+	changes := &LabelChanges{}
+	description := fmt.Sprintf("rename @%s to @%s", oldName, newName)
+	label, ok := findLabelByName(labels, oldName)
+	if ok {
+		changes.add(description, newCommand("label_update", map[string]any{
+			"id":   label.ID,
+			"name": newName,
+		}))
+	} else {
+		changes.add(description, newCommand("label_rename", map[string]any{
+			"name_old": oldName,
+			"name_new": newName,
+		}))
+	}
+
+	return changes, t.applyLabelChanges(changes, dryRun)
+}
+
+// MergeLabels replaces label `from` with label `into` on all tasks and deletes `from`.
+func (t *Client) MergeLabels(from, into string, dryRun bool) (*LabelChanges, error) {
+	labels := t.getLabelList()
+	tasks := t.getTasks()
+
+	changes := &LabelChanges{}
+	for _, task := range tasks {
+		if !slices.Contains(task.Labels, from) {
+			continue
+		}
+
+		newLabels := make([]string, 0, len(task.Labels))
+		for _, name := range task.Labels {
+			if name == from {
+				name = into
+			}
+			if !slices.Contains(newLabels, name) {
+				newLabels = append(newLabels, name)
+			}
+		}
+
+		changes.add(fmt.Sprintf("relabel `%s`: @%s -> @%s", task.Content, from, into), newCommand("item_update", map[string]any{
+			"id":     task.ID,
+			"labels": newLabels,
+		}))
+	}
+
+	if label, ok := findLabelByName(labels, from); ok {
+		changes.add(fmt.Sprintf("delete @%s", from), newCommand("label_delete", map[string]any{
+			"id": label.ID,
+		}))
+	}
+
+	return changes, t.applyLabelChanges(changes, dryRun)
+}
+
+// DeleteUnusedLabels deletes personal labels that no open task uses, except the kept ones.
+func (t *Client) DeleteUnusedLabels(keep []string, dryRun bool) (*LabelChanges, error) {
+	labels := t.getLabelList()
+	usage := labelUsage(labels, t.getTasks())
+
+	changes := &LabelChanges{}
+	for _, u := range usage {
+		if !u.Personal || u.Count > 0 || slices.Contains(keep, u.Name) {
+			continue
+		}
+
+		label, _ := findLabelByName(labels, u.Name)
+		changes.add(fmt.Sprintf("delete unused @%s", u.Name), newCommand("label_delete", map[string]any{
+			"id": label.ID,
+		}))
+	}
+
+	return changes, t.applyLabelChanges(changes, dryRun)
+}
+
+func findLabelByName(labels []Label, name string) (*Label, bool) {
+	for _, l := range labels {
+		if l.Name == name {
+			return &l, true
+		}
+	}
+	return nil, false
+}
+
+func (c *LabelChanges) add(change string, command Command) {
+	c.Changes = append(c.Changes, change)
+	c.Commands = append(c.Commands, command)
+}
+
+func (t *Client) applyLabelChanges(changes *LabelChanges, dryRun bool) error {
+	for _, change := range changes.Changes {
+		if dryRun {
+			log.Printf("dry run: %s", change)
+		} else {
+			log.Print(change)
+		}
+	}
+
+	if dryRun || len(changes.Commands) == 0 {
+		return nil
+	}
+	return t.doSyncCommandsInBatches(changes.Commands)
+}
+
+func PrettyLabelUsageOutput(usage []LabelUsage) string {
+	builder := strings.Builder{}
+	for _, u := range usage {
+		kind := "shared"
+		if u.Personal {
+			kind = "personal"
+		}
+		builder.WriteString(fmt.Sprintf("%5d  @%s  %s\n", u.Count, u.Name, kind))
+	}
+	return builder.String()
+}
@@ -0,0 +1,182 @@
+package todoist
+
+import (
+	"slices"
+	"testing"
+)
+
+func newLabelsAPI() *MemoryAPI {
+	return &MemoryAPI{
+		Projects: []Project{{ID: "inbox", Name: "Inbox"}},
+		Labels: []Label{
+			{ID: "l1", Name: "next"},
+			{ID: "l2", Name: "next_action"},
+			{ID: "l3", Name: "unused"},
+			{ID: "l4", Name: "reference"},
+		},
+		Tasks: []Task{
+			{ID: "1", ProjectID: "inbox", Content: "Call", Labels: []string{"next", "next_action"}},
+			{ID: "2", ProjectID: "inbox", Content: "Email", Labels: []string{"next", "home"}},
+			{ID: "3", ProjectID: "inbox", Content: "Read", Labels: []string{"next_action", "shared"}},
+		},
+	}
+}
+
+func taskLabels(api *MemoryAPI) map[string][]string {
+	labels := map[string][]string{}
+	for _, task := range api.Tasks {
+		labels[task.ID] = task.Labels
+	}
+	return labels
+}
+
+func labelNames(api *MemoryAPI) []string {
+	names := make([]string, 0, len(api.Labels))
+	for _, label := range api.Labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+func assertTaskLabels(t *testing.T, api *MemoryAPI, want map[string][]string) {
+	t.Helper()
+	got := taskLabels(api)
+	for id, labels := range want {
+		if !slices.Equal(got[id], labels) {
+			t.Errorf("task %s labels = %v, want %v", id, got[id], labels)
+		}
+	}
+}
+
+func TestRenameLabel(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldName    string
+		newName    string
+		wantType   string
+		wantLabels []string
+		wantTasks  map[string][]string
+	}{
+		{
+			name:       "personal label is updated in place",
+			oldName:    "next",
+			newName:    "now",
+			wantType:   "label_update",
+			wantLabels: []string{"now", "next_action", "unused", "reference"},
+			wantTasks:  map[string][]string{"1": {"now", "next_action"}, "2": {"now", "home"}},
+		},
+		{
+			name:       "shared label is renamed on tasks",
+			oldName:    "shared",
+			newName:    "team",
+			wantType:   "label_rename",
+			wantLabels: []string{"next", "next_action", "unused", "reference"},
+			wantTasks:  map[string][]string{"3": {"next_action", "team"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newLabelsAPI()
+			client := NewClientWithAPI(api)
+
+			changes, err := client.RenameLabel(tt.oldName, tt.newName, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(changes.Commands) != 1 || changes.Commands[0].Type != tt.wantType {
+				t.Fatalf("commands = %+v, want one %s", changes.Commands, tt.wantType)
+			}
+			if got := labelNames(api); !slices.Equal(got, tt.wantLabels) {
+				t.Errorf("labels = %v, want %v", got, tt.wantLabels)
+			}
+			assertTaskLabels(t, api, tt.wantTasks)
+		})
+	}
+}
+
+func TestMergeLabels(t *testing.T) {
+	api := newLabelsAPI()
+	client := NewClientWithAPI(api)
+
+	changes, err := client.MergeLabels("next", "next_action", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes.Commands) != 3 {
+		t.Errorf("got %d commands, want 2 relabels and a delete", len(changes.Commands))
+	}
+	if got, want := labelNames(api), []string{"next_action", "unused", "reference"}; !slices.Equal(got, want) {
+		t.Errorf("labels = %v, want %v", got, want)
+	}
+	assertTaskLabels(t, api, map[string][]string{
+		// the task already had the target label, which is kept once
+		"1": {"next_action"},
+		"2": {"next_action", "home"},
+		"3": {"next_action", "shared"},
+	})
+}
+
+func TestDeleteUnusedLabels(t *testing.T) {
+	api := newLabelsAPI()
+	client := NewClientWithAPI(api)
+
+	changes, err := client.DeleteUnusedLabels([]string{"reference"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"delete unused @unused"}; !slices.Equal(changes.Changes, want) {
+		t.Errorf("changes = %v, want %v", changes.Changes, want)
+	}
+	if got, want := labelNames(api), []string{"next", "next_action", "reference"}; !slices.Equal(got, want) {
+		t.Errorf("labels = %v, want %v", got, want)
+	}
+}
+
+func TestLabelChangesDryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		run         func(client *Client) (*LabelChanges, error)
+		wantChanges int
+	}{
+		{
+			name:        "rename",
+			run:         func(client *Client) (*LabelChanges, error) { return client.RenameLabel("next", "now", true) },
+			wantChanges: 1,
+		},
+		{
+			name:        "merge",
+			run:         func(client *Client) (*LabelChanges, error) { return client.MergeLabels("next", "next_action", true) },
+			wantChanges: 3,
+		},
+		{
+			name:        "delete unused",
+			run:         func(client *Client) (*LabelChanges, error) { return client.DeleteUnusedLabels(nil, true) },
+			wantChanges: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newLabelsAPI()
+			before := taskLabels(api)
+			client := NewClientWithAPI(api)
+
+			changes, err := tt.run(client)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(changes.Changes) != tt.wantChanges || len(changes.Commands) != tt.wantChanges {
+				t.Errorf("got %d changes and %d commands, want %d", len(changes.Changes), len(changes.Commands), tt.wantChanges)
+			}
+			if len(api.Commands) != 0 || len(api.Labels) != 4 {
+				t.Errorf("dry run applied %d commands, %d labels left", len(api.Commands), len(api.Labels))
+			}
+			assertTaskLabels(t, api, before)
+		})
+	}
+}
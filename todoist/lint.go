@@ -0,0 +1,250 @@
+package todoist
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+)
+
+// LintRule is a check applied to open tasks by LintTasks.
+type LintRule string
+
+const (
+	// LintVerb flags @next_action tasks whose first word is not a known verb.
+	LintVerb LintRule = "verb"
+	// LintLength flags titles longer than TaskLintConfig.MaxTitleLength.
+	LintLength LintRule = "length"
+	// LintP1Description flags p1 tasks without description.
+	LintP1Description LintRule = "p1_description"
+	// LintMultipleActions flags titles joining several actions with a conjunction or ";".
+	LintMultipleActions LintRule = "multiple_actions"
+	// LintBareURL flags tasks that are nothing but a link.
+	LintBareURL LintRule = "bare_url"
+)
+
+var AllLintRules = []LintRule{LintVerb, LintLength, LintP1Description, LintMultipleActions, LintBareURL}
+
+const DefaultMaxTitleLength = 80
+
+// LintLanguage holds the words used by the verb and multiple actions rules for one language.
+type LintLanguage struct {
+	Verbs        []string
+	Conjunctions []string
+}
+
+// DefaultLintLanguages are the built-in word lists, keyed by language code.
+var DefaultLintLanguages = map[string]LintLanguage{
+	"en": {
+		Verbs: []string{
+			"add", "ask", "book", "buy", "call", "cancel", "check", "clean", "collect", "configure", "confirm",
+			"contact", "create", "decide", "define", "delete", "deploy", "design", "discuss", "do", "download",
+			"draft", "email", "find", "finish", "fix", "follow", "get", "install", "investigate", "learn",
+			"list", "make", "meet", "message", "move", "order", "organize", "pay", "pick", "plan", "prepare",
+			"print", "read", "refactor", "register", "remove", "renew", "reply", "report", "research", "review",
+			"schedule", "send", "set", "setup", "share", "sign", "start", "submit", "test", "text", "update",
+			"upload", "visit", "wash", "watch", "write",
+		},
+		Conjunctions: []string{"and"},
+	},
+	"uk": {
+		Verbs: []string{
+			"видалити", "виправити", "вивчити", "відповісти", "відправити", "дізнатися", "додати", "домовитися",
+			"дописати", "забрати", "завантажити", "заплатити", "записатися", "замовити", "знайти", "зробити",
+			"купити", "налаштувати", "написати", "перевірити", "переглянути", "підготувати", "підписати",
+			"подзвонити", "почати", "прибрати", "прочитати", "скасувати", "спланувати", "створити", "оновити",
+			"оплатити", "організувати", "обрати", "вирішити",
+		},
+		Conjunctions: []string{"і", "й", "та"},
+	},
+}
+
+// TaskLintConfig configures LintTasks.
+type TaskLintConfig struct {
+	Rules []LintRule
+	// Languages selects word lists from DefaultLintLanguages, defaults to English.
+	Languages []string
+	// Verbs replaces the verb list of a language.
+	Verbs map[string][]string
+	// MaxTitleLength defaults to DefaultMaxTitleLength.
+	MaxTitleLength int
+}
+
+// ParseTaskLintConfig builds the config from comma separated rules ("all" enables every rule) and languages,
+// and verb lists in the `en=call,email;uk=купити` format. Empty rules disable linting and return nil.
+func ParseTaskLintConfig(rules string, languages string, verbs string) (*TaskLintConfig, error) {
+	if rules == "" {
+		return nil, nil
+	}
+
+	config := &TaskLintConfig{
+		Rules: make([]LintRule, 0),
+		Verbs: map[string][]string{},
+	}
+
+	for _, part := range strings.Split(rules, ",") {
+		rule := LintRule(strings.TrimSpace(part))
+		switch {
+		case rule == "all":
+			config.Rules = AllLintRules
+		case slices.Contains(AllLintRules, rule):
+			config.Rules = append(config.Rules, rule)
+		default:
+			return nil, fmt.Errorf("invalid lint rule `%s`", part)
+		}
+	}
+
+	if languages != "" {
+		for _, language := range strings.Split(languages, ",") {
+			config.Languages = append(config.Languages, strings.TrimSpace(language))
+		}
+	}
+
+	if verbs != "" {
+		for _, pair := range strings.Split(verbs, ";") {
+			language, words, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid lint verbs `%s`, expected `language=verb,verb`", pair)
+			}
+			config.Verbs[language] = strings.Split(words, ",")
+		}
+	}
+
+	return config, nil
+}
+
+func (c TaskLintConfig) enabled(rule LintRule) bool {
+	return slices.Contains(c.Rules, rule)
+}
+
+// words returns lowercased verbs and conjunctions of the configured languages.
+func (c TaskLintConfig) words() (verbs map[string]bool, conjunctions map[string]bool) {
+	languages := c.Languages
+	if len(languages) == 0 {
+		languages = []string{"en"}
+	}
+
+	verbs, conjunctions = map[string]bool{}, map[string]bool{}
+	for _, language := range languages {
+		languageVerbs, ok := c.Verbs[language]
+		if !ok {
+			languageVerbs = DefaultLintLanguages[language].Verbs
+		}
+		for _, verb := range languageVerbs {
+			verbs[strings.ToLower(strings.TrimSpace(verb))] = true
+		}
+		for _, conjunction := range DefaultLintLanguages[language].Conjunctions {
+			conjunctions[conjunction] = true
+		}
+	}
+	return verbs, conjunctions
+}
+
+// LintTasks checks open tasks against the enabled rules and returns a row per finding,
+// placed under the task's project.
+func (t *Client) LintTasks(config TaskLintConfig) []IncorrectProjectSchema {
+	tree := NewProjectTree(t.getProjectList())
+	tasks := t.getTasks()
+
+	tasksByProjectID := map[string][]Task{}
+	for _, task := range tasks {
+		tasksByProjectID[task.ProjectID] = append(tasksByProjectID[task.ProjectID], task)
+	}
+
+	verbs, conjunctions := config.words()
+	maxTitleLength := config.MaxTitleLength
+	if maxTitleLength == 0 {
+		maxTitleLength = DefaultMaxTitleLength
+	}
+
+	rows := make([]IncorrectProjectSchema, 0)
+	tree.Walk(func(node *ProjectNode) {
+		projectTasks := tasksByProjectID[node.ID]
+		slices.SortStableFunc(projectTasks, func(a, b Task) int {
+			return a.Order - b.Order
+		})
+
+		for _, task := range projectTasks {
+			for _, finding := range lintTask(task, config, verbs, conjunctions, maxTitleLength) {
+				rows = append(rows, IncorrectProjectSchema{
+					ProjectName: node.Name,
+					Path:        node.Path,
+					Depth:       node.Depth,
+					TaskContent: task.Content,
+					URL:         fmt.Sprintf("https://todoist.com/showTask?id=%s", task.ID),
+					Description: finding,
+				})
+			}
+		}
+	})
+
+	return rows
+}
+
+var urlRegexp = regexp.MustCompile(`https?://\S+`)
+
+func lintTask(task Task, config TaskLintConfig, verbs map[string]bool, conjunctions map[string]bool, maxTitleLength int) []string {
+	findings := make([]string, 0)
+	words := strings.FieldsFunc(strings.ToLower(urlRegexp.ReplaceAllString(task.Content, " ")), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '-'
+	})
+
+	if config.enabled(LintVerb) && task.IsNextAction() && len(words) > 0 && !verbs[words[0]] {
+		findings = append(findings, "does not start with a verb")
+	}
+
+	if config.enabled(LintLength) && utf8.RuneCountInString(task.Content) > maxTitleLength {
+		findings = append(findings, fmt.Sprintf("title is longer than %d characters", maxTitleLength))
+	}
+
+	if config.enabled(LintP1Description) && task.Priority == P1 && strings.TrimSpace(task.Description) == "" {
+		findings = append(findings, "p1 task without description")
+	}
+
+	if config.enabled(LintMultipleActions) {
+		multiple := strings.Contains(task.Content, ";")
+		for _, word := range words {
+			multiple = multiple || conjunctions[word]
+		}
+		if multiple {
+			findings = append(findings, "contains multiple actions")
+		}
+	}
+
+	if config.enabled(LintBareURL) && urlRegexp.MatchString(task.Content) && len(words) == 0 {
+		findings = append(findings, "link without context")
+	}
+
+	return findings
+}
+
+// maxLintOutputRows keeps the lint message under the Telegram message length limit.
+const maxLintOutputRows = 30
+
+// PrettyLintOutput lists up to maxLintOutputRows findings under their projects.
+func (t *Client) PrettyLintOutput(rows []IncorrectProjectSchema) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString("tasks to rewrite:\n")
+	lastPath := ""
+	for _, row := range rows[:min(len(rows), maxLintOutputRows)] {
+		if row.Path != lastPath {
+			builder.WriteString(fmt.Sprintf("%s:\n", telegram.EscapeText(row.Path)))
+			lastPath = row.Path
+		}
+		builder.WriteString(fmt.Sprintf("    [%s](%s) - %s\n", telegram.EscapeText(row.TaskContent), row.URL, row.Description))
+	}
+	if len(rows) > maxLintOutputRows {
+		builder.WriteString(fmt.Sprintf("and %d more\n", len(rows)-maxLintOutputRows))
+	}
+
+	return builder.String()
+}
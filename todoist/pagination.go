@@ -0,0 +1,132 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the number of items requested per page from paginated endpoints.
+const DefaultPageSize = 200
+
+// PageIterator walks an endpoint page by page, following `next_cursor` until it is empty.
+// Endpoints that return a plain JSON array are treated as a single page.
+//
+//	it := newPageIterator[Task](h, tasksUrl)
+//	for it.Next(ctx) {
+//		tasks = append(tasks, it.Page()...)
+//	}
+//	if err := it.Err(); err != nil { ... }
+type PageIterator[T any] struct {
+	client  *httpAPI
+	baseUrl string
+	cursor  string
+	done    bool
+	page    []T
+	err     error
+}
+
+// cursorPage is a page of the unified API. Most endpoints return `results`, completed tasks return `items`.
+type cursorPage[T any] struct {
+	Results    []T     `json:"results"`
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+func newPageIterator[T any](client *httpAPI, baseUrl string) *PageIterator[T] {
+	return &PageIterator[T]{
+		client:  client,
+		baseUrl: baseUrl,
+	}
+}
+
+// Next fetches the next page and reports whether there is one.
+// It returns false when all pages were read, a request failed or ctx was cancelled.
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	pageUrl, err := it.pageUrl()
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	b, err := it.client.doGetRequest(ctx, pageUrl)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	if b = bytes.TrimSpace(b); len(b) > 0 && b[0] == '[' {
+		it.page = nil
+		it.err = json.Unmarshal(b, &it.page)
+		it.done = true
+		return it.err == nil
+	}
+
+	var page cursorPage[T]
+	err = json.Unmarshal(b, &page)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.page = page.Results
+	if len(page.Items) > 0 {
+		it.page = page.Items
+	}
+	if page.NextCursor == nil || *page.NextCursor == "" {
+		it.done = true
+	} else {
+		it.cursor = *page.NextCursor
+	}
+	return true
+}
+
+// Page returns the items of the page fetched by the last call to Next.
+func (it *PageIterator[T]) Page() []T {
+	return it.page
+}
+
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+func (it *PageIterator[T]) pageUrl() (string, error) {
+	u, err := url.Parse(it.baseUrl)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set("limit", strconv.Itoa(it.client.pageSize))
+	if it.cursor != "" {
+		query.Set("cursor", it.cursor)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// listAll reads all pages of an endpoint.
+func listAll[T any](ctx context.Context, client *httpAPI, baseUrl string) ([]T, error) {
+	items := make([]T, 0)
+
+	it := newPageIterator[T](client, baseUrl)
+	for it.Next(ctx) {
+		items = append(items, it.Page()...)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
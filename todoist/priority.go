@@ -0,0 +1,91 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Priority is a task priority as shown in the Todoist UI, where P1 is the most urgent.
+// The REST and Sync APIs use the reverse scale (4 is p1), see PriorityFromAPI and Priority.API.
+type Priority int
+
+const (
+	P1 Priority = 1
+	P2 Priority = 2
+	P3 Priority = 3
+	P4 Priority = 4
+)
+
+// DefaultProtectedPriority is the lowest priority that MoveInactiveTasks never moves, i.e. p1 and p2 tasks are kept.
+const DefaultProtectedPriority = P2
+
+func PriorityFromAPI(apiPriority int) Priority {
+	return Priority(5 - apiPriority)
+}
+
+// API returns the priority as expected by the Todoist APIs.
+func (p Priority) API() int {
+	return 5 - int(p)
+}
+
+// ParsePriority parses "p1".."p4" (case-insensitive) or "1".."4" in UI terms.
+func ParsePriority(s string) (Priority, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "p"))
+	if err != nil || n < int(P1) || n > int(P4) {
+		return 0, fmt.Errorf("invalid priority `%s`, expected p1..p4", s)
+	}
+	return Priority(n), nil
+}
+
+// AtLeast reports whether p is as urgent as other or more, e.g. P1.AtLeast(P2) is true.
+func (p Priority) AtLeast(other Priority) bool {
+	return p != 0 && p <= other
+}
+
+func (p Priority) String() string {
+	if p == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("p%d", int(p))
+}
+
+// MarshalJSON writes the priority as "p1".."p4".
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON reads API priorities (numbers, where 4 is p1) and "p1".."p4" strings.
+func (p *Priority) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var apiPriority int
+	if err := json.Unmarshal(b, &apiPriority); err == nil {
+		*p = PriorityFromAPI(apiPriority)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	priority, err := ParsePriority(s)
+	if err != nil {
+		return err
+	}
+	*p = priority
+	return nil
+}
+
+// UnmarshalText reads "p1".."p4", e.g. in YAML templates.
+func (p *Priority) UnmarshalText(text []byte) error {
+	priority, err := ParsePriority(string(text))
+	if err != nil {
+		return err
+	}
+	*p = priority
+	return nil
+}
@@ -0,0 +1,227 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+)
+
+// NameCase is a capitalisation convention for project names.
+type NameCase string
+
+const (
+	// LowerCase names have no capital letters.
+	LowerCase NameCase = "lower"
+	// SentenceCase names start with a capital letter.
+	SentenceCase NameCase = "sentence"
+	// TitleCase names start every word with a capital letter.
+	TitleCase NameCase = "title"
+)
+
+// ProjectLintConfig configures LintProjects. Zero values disable the corresponding check.
+type ProjectLintConfig struct {
+	// EmojiPrefix requires names to start with an emoji.
+	EmojiPrefix bool
+	// NamePattern is a regular expression every name must match.
+	NamePattern string
+	// NameCase is checked on the name without its emoji prefix.
+	NameCase NameCase
+	// MaxDepth is the maximum number of hierarchy levels, 1 allows only root projects.
+	MaxDepth int
+	// ForbidDuplicateNames reports projects sharing a name, which makes lookups by name ambiguous.
+	ForbidDuplicateNames bool
+	// SectionsAfterTasks reports projects without sections that have more open tasks than this.
+	SectionsAfterTasks int
+	// AreaColors maps area projects, by name or path, to the colour their whole subtree must use.
+	AreaColors map[string]string
+	// Exclude lists projects that are not checked, matched with ProjectNode.MatchesAny.
+	Exclude []string
+
+	// namePattern is NamePattern compiled by ParseProjectLintConfig.
+	namePattern *regexp.Regexp
+}
+
+// ParseProjectLintConfig reads the config from JSON, an empty string disables project linting and returns nil.
+func ParseProjectLintConfig(s string) (*ProjectLintConfig, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var config ProjectLintConfig
+	err := json.Unmarshal([]byte(s), &config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project lint config: %w", err)
+	}
+	switch config.NameCase {
+	case "", LowerCase, SentenceCase, TitleCase:
+	default:
+		return nil, fmt.Errorf("invalid name case `%s`, expected lower, sentence or title", config.NameCase)
+	}
+	if config.NamePattern != "" {
+		config.namePattern, err = regexp.Compile(config.NamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project name pattern: %w", err)
+		}
+	}
+	return &config, nil
+}
+
+// LintProjects checks project names and structure against config and returns a row per finding.
+// The Inbox is never checked.
+func (t *Client) LintProjects(config ProjectLintConfig) []IncorrectProjectSchema {
+	tree := NewProjectTree(t.getProjectList())
+	if config.SectionsAfterTasks > 0 {
+		tree.SetSections(t.getSectionList())
+	}
+
+	tasksCount := map[string]int{}
+	if config.SectionsAfterTasks > 0 {
+		for _, task := range t.getTasks() {
+			tasksCount[task.ProjectID]++
+		}
+	}
+
+	return lintProjects(tree, tasksCount, config)
+}
+
+func lintProjects(tree *ProjectTree, tasksCount map[string]int, config ProjectLintConfig) []IncorrectProjectSchema {
+	namePattern := config.namePattern
+	if namePattern == nil && config.NamePattern != "" {
+		// configs that were not parsed are compiled here, an invalid pattern only disables its check
+		var err error
+		namePattern, err = regexp.Compile(config.NamePattern)
+		if err != nil {
+			log.Printf("invalid project name pattern, %v", err)
+		}
+	}
+
+	pathsByName := map[string][]string{}
+	tree.Walk(func(node *ProjectNode) {
+		pathsByName[node.Name] = append(pathsByName[node.Name], node.Path)
+	})
+
+	rows := make([]IncorrectProjectSchema, 0)
+	tree.Walk(func(node *ProjectNode) {
+		if node.IsInboxProject || node.MatchesAny(config.Exclude) {
+			return
+		}
+
+		report := func(description string) {
+			rows = append(rows, IncorrectProjectSchema{
+				ProjectName: node.Name,
+				Path:        node.Path,
+				Depth:       node.Depth,
+				TasksCount:  tasksCount[node.ID],
+				URL:         node.Url,
+				Description: description,
+			})
+		}
+
+		name, hasEmoji := trimEmojiPrefix(node.Name)
+		if config.EmojiPrefix && !hasEmoji {
+			report("name does not start with an emoji")
+		}
+		if namePattern != nil && !namePattern.MatchString(node.Name) {
+			report("name does not match the naming pattern")
+		}
+		if config.NameCase != "" && !matchesCase(name, config.NameCase) {
+			report(fmt.Sprintf("name is not in %s case", config.NameCase))
+		}
+
+		if config.MaxDepth > 0 && node.Depth+1 > config.MaxDepth {
+			report(fmt.Sprintf("nested deeper than %d levels", config.MaxDepth))
+		}
+
+		if config.ForbidDuplicateNames && len(pathsByName[node.Name]) > 1 {
+			others := make([]string, 0)
+			for _, path := range pathsByName[node.Name] {
+				if path != node.Path {
+					others = append(others, telegram.EscapeText(path))
+				}
+			}
+			report(fmt.Sprintf("name is also used by %s", strings.Join(others, ", ")))
+		}
+
+		if config.SectionsAfterTasks > 0 && len(node.Sections) == 0 && tasksCount[node.ID] > config.SectionsAfterTasks {
+			report(fmt.Sprintf("%d tasks without sections", tasksCount[node.ID]))
+		}
+
+		if color, ok := areaColorFor(node, config.AreaColors); ok && node.Color != color {
+			report(fmt.Sprintf("colour is %s instead of %s", node.Color, color))
+		}
+	})
+
+	return rows
+}
+
+// areaColorFor returns the colour of the closest area the node belongs to, including the node itself.
+func areaColorFor(node *ProjectNode, areaColors map[string]string) (string, bool) {
+	for n := node; n != nil; n = n.Parent {
+		if color, ok := areaColors[n.Path]; ok {
+			return color, true
+		}
+		if color, ok := areaColors[n.Name]; ok {
+			return color, true
+		}
+	}
+	return "", false
+}
+
+// trimEmojiPrefix returns the name without a leading emoji and reports whether there was one.
+func trimEmojiPrefix(name string) (string, bool) {
+	r, _ := utf8.DecodeRuneInString(name)
+	// empty names and invalid UTF-8 decode to RuneError, which is a symbol too
+	if r == utf8.RuneError || !isEmoji(r) {
+		return name, false
+	}
+
+	trimmed := strings.TrimLeftFunc(name, func(r rune) bool {
+		// variation selectors and zero width joiners are part of composed emoji
+		return isEmoji(r) || unicode.Is(unicode.Variation_Selector, r) || r == '\u200d' || unicode.IsSpace(r)
+	})
+	return trimmed, true
+}
+
+func isEmoji(r rune) bool {
+	return unicode.Is(unicode.So, r) || (r >= 0x1F000 && r <= 0x1FAFF)
+}
+
+func matchesCase(name string, nameCase NameCase) bool {
+	switch nameCase {
+	case LowerCase:
+		return name == strings.ToLower(name)
+	case SentenceCase:
+		r, _ := utf8.DecodeRuneInString(name)
+		return !unicode.IsLower(r)
+	case TitleCase:
+		for _, word := range strings.Fields(name) {
+			r, _ := utf8.DecodeRuneInString(word)
+			if unicode.IsLower(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// PrettyProjectLintOutput lists findings in the project hierarchy.
+func (t *Client) PrettyProjectLintOutput(rows []IncorrectProjectSchema) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString("projects breaking conventions:\n")
+	writeProjectRows(&builder, rows, func(p IncorrectProjectSchema) string {
+		return fmt.Sprintf("[%s](%s) - %s", p.displayName(), p.URL, p.Description)
+	})
+
+	return builder.String()
+}
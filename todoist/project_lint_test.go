@@ -0,0 +1,157 @@
+package todoist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLintProjects(t *testing.T) {
+	projects := []Project{
+		{ID: "inbox", Name: "Inbox", IsInboxProject: true},
+		{ID: "work", Name: "💼 Work", Color: "blue"},
+		{ID: "clients", Name: "💼 Clients", ParentID: "work", Color: "red"},
+		{ID: "acme", Name: "💼 Acme [old]", ParentID: "clients", Color: "blue"},
+		{ID: "home", Name: "home", Color: "green"},
+		{ID: "archive", Name: "💼 Acme [old]", Color: "grey"},
+	}
+	sections := []Section{{ID: "s1", ProjectID: "work", Name: "Now"}}
+	tasksCount := map[string]int{"work": 10, "home": 10}
+
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{
+			name:   "emoji prefix",
+			config: `{"EmojiPrefix": true}`,
+			want:   []string{"home: name does not start with an emoji"},
+		},
+		{
+			name:   "name pattern",
+			config: `{"NamePattern": "^\\S+ [A-Z]"}`,
+			want:   []string{"home: name does not match the naming pattern"},
+		},
+		{
+			name:   "title case without emoji",
+			config: `{"NameCase": "title"}`,
+			want:   []string{"home: name is not in title case"},
+		},
+		{
+			name:   "max depth",
+			config: `{"MaxDepth": 2}`,
+			want:   []string{"💼 Work/💼 Clients/💼 Acme [old]: nested deeper than 2 levels"},
+		},
+		{
+			name:   "duplicate names are escaped",
+			config: `{"ForbidDuplicateNames": true}`,
+			want: []string{
+				`💼 Work/💼 Clients/💼 Acme [old]: name is also used by 💼 Acme \[old\]`,
+				`💼 Acme [old]: name is also used by 💼 Work/💼 Clients/💼 Acme \[old\]`,
+			},
+		},
+		{
+			name:   "sections after tasks",
+			config: `{"SectionsAfterTasks": 5}`,
+			want:   []string{"home: 10 tasks without sections"},
+		},
+		{
+			name:   "area colours apply to the closest area",
+			config: `{"AreaColors": {"💼 Work": "blue", "💼 Work/💼 Clients": "red"}}`,
+			want:   []string{"💼 Work/💼 Clients/💼 Acme [old]: colour is blue instead of red"},
+		},
+		{
+			name:   "excluded projects",
+			config: `{"EmojiPrefix": true, "MaxDepth": 2, "Exclude": ["home", "💼 Work/**"]}`,
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseProjectLintConfig(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tree := NewProjectTree(projects)
+			tree.SetSections(sections)
+
+			rows := lintProjects(tree, tasksCount, *config)
+
+			got := make([]string, 0, len(rows))
+			for _, row := range rows {
+				got = append(got, row.Path+": "+row.Description)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("findings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintProjectsInvalidPatternDoesNotPanic(t *testing.T) {
+	tree := NewProjectTree([]Project{{ID: "1", Name: "Work"}})
+
+	rows := lintProjects(tree, nil, ProjectLintConfig{NamePattern: "("})
+
+	if len(rows) != 0 {
+		t.Errorf("findings = %+v, want none", rows)
+	}
+}
+
+func TestParseProjectLintConfig(t *testing.T) {
+	config, err := ParseProjectLintConfig("")
+	if err != nil || config != nil {
+		t.Errorf("empty config = %+v, %v, want nil", config, err)
+	}
+
+	for _, s := range []string{`{"NamePattern": "("}`, `{"NameCase": "camel"}`, `{`} {
+		if _, err := ParseProjectLintConfig(s); err == nil {
+			t.Errorf("expected an error for %s", s)
+		}
+	}
+}
+
+func TestTrimEmojiPrefix(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantName  string
+		wantEmoji bool
+	}{
+		{name: "💼 Work", wantName: "Work", wantEmoji: true},
+		{name: "🏃‍♂️ Running", wantName: "Running", wantEmoji: true},
+		{name: "❤️Health", wantName: "Health", wantEmoji: true},
+		{name: "Work 💼", wantName: "Work 💼", wantEmoji: false},
+		{name: "", wantName: "", wantEmoji: false},
+	}
+
+	for _, tt := range tests {
+		name, hasEmoji := trimEmojiPrefix(tt.name)
+		if name != tt.wantName || hasEmoji != tt.wantEmoji {
+			t.Errorf("trimEmojiPrefix(%q) = %q, %v, want %q, %v", tt.name, name, hasEmoji, tt.wantName, tt.wantEmoji)
+		}
+	}
+}
+
+func TestMatchesCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		nameCase NameCase
+		want     bool
+	}{
+		{name: "side projects", nameCase: LowerCase, want: true},
+		{name: "Side projects", nameCase: LowerCase, want: false},
+		{name: "Side projects", nameCase: SentenceCase, want: true},
+		{name: "side projects", nameCase: SentenceCase, want: false},
+		{name: "2024 plans", nameCase: SentenceCase, want: true},
+		{name: "Side Projects", nameCase: TitleCase, want: true},
+		{name: "Side projects", nameCase: TitleCase, want: false},
+		{name: "Проєкти Вдома", nameCase: TitleCase, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := matchesCase(tt.name, tt.nameCase); got != tt.want {
+			t.Errorf("matchesCase(%q, %s) = %v, want %v", tt.name, tt.nameCase, got, tt.want)
+		}
+	}
+}
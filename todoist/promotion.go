@@ -0,0 +1,246 @@
+package todoist
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+)
+
+// PromotionStrategy decides which task becomes the next action of a project or section without one.
+type PromotionStrategy string
+
+const (
+	// PromoteByOrder picks the first task in the UI order: tasks without section, then the first section.
+	PromoteByOrder PromotionStrategy = "order"
+	// PromoteByPriority picks the most urgent task, falling back to the UI order.
+	PromoteByPriority PromotionStrategy = "priority"
+)
+
+// NextActionPromotionConfig configures PromoteNextActions.
+type NextActionPromotionConfig struct {
+	Strategy PromotionStrategy
+	DryRun   bool
+}
+
+// ParseNextActionPromotionConfig builds the config from a strategy name. An empty strategy disables promotion
+// and returns nil.
+func ParseNextActionPromotionConfig(strategy string) (*NextActionPromotionConfig, error) {
+	switch s := PromotionStrategy(strings.TrimSpace(strategy)); s {
+	case "":
+		return nil, nil
+	case PromoteByOrder, PromoteByPriority:
+		return &NextActionPromotionConfig{Strategy: s}, nil
+	default:
+		return nil, fmt.Errorf("invalid promotion strategy `%s`, expected order or priority", strategy)
+	}
+}
+
+// PromotedTask is a task that got @next_action because its project or section had none.
+type PromotedTask struct {
+	TaskID      string `json:"taskID,omitempty"`
+	Content     string `json:"content"`
+	ProjectName string `json:"projectName"`
+	SectionName string `json:"sectionName,omitempty"`
+	Path        string `json:"path"`
+	Depth       int    `json:"depth,omitempty"`
+	// Created is set when the project or section had no tasks and a placeholder task was added.
+	Created bool   `json:"created,omitempty"`
+	URL     string `json:"url"`
+}
+
+// PromoteNextActions adds @next_action to the best candidate task of every project and section reported by
+// GetProjectsWithTooManyAndZeroTasks as having none, or creates a placeholder task when there is no candidate.
+// Only top-level tasks without any of GTDLabels, e.g. @waiting_for, are candidates. A project without candidates whose subprojects are
+// checked too is left to them.
+func (t *Client) PromoteNextActions(checkConfig NextActionCheckConfig, promoteConfig NextActionPromotionConfig) ([]PromotedTask, error) {
+	tree := NewProjectTree(t.getProjectList())
+	tree.SetSections(t.getSectionList())
+	tasks := t.getTasks()
+	nextActionTasks := t.mapTasksToProjectAndFilterByLabel(tree, tasks)
+
+	candidates := map[string][]Task{}
+	for _, task := range tasks {
+		if task.ParentID == "" && !hasGTDLabel(task) {
+			candidates[task.ProjectID] = append(candidates[task.ProjectID], task)
+		}
+	}
+
+	rows := t.findProjectsWithZeroTasks(tree, nextActionTasks, checkConfig)
+	promoted := findPromotions(tree, rows, candidates, checkConfig, promoteConfig.Strategy)
+
+	commands := make([]Command, 0, len(promoted))
+	for _, p := range promoted {
+		commands = append(commands, p.command)
+	}
+
+	result := make([]PromotedTask, 0, len(promoted))
+	for _, p := range promoted {
+		result = append(result, p.PromotedTask)
+	}
+
+	if promoteConfig.DryRun {
+		return result, nil
+	}
+	return result, t.doSyncCommandsInBatches(commands)
+}
+
+type promotion struct {
+	PromotedTask
+	command Command
+}
+
+func findPromotions(
+	tree *ProjectTree,
+	rows []IncorrectProjectSchema,
+	candidates map[string][]Task,
+	checkConfig NextActionCheckConfig,
+	strategy PromotionStrategy,
+) []promotion {
+	promoted := make([]promotion, 0)
+	// satisfied holds project and section IDs that got a next action from an earlier row
+	satisfied := map[string]bool{}
+
+	for _, row := range rows {
+		node, section, ok := findRowTarget(tree, row)
+		if !ok {
+			continue
+		}
+
+		targetID, projectCandidates := node.ID, candidates[node.ID]
+		if section != nil {
+			targetID, projectCandidates = section.ID, sectionTasks(projectCandidates, section.ID)
+		}
+		if satisfied[targetID] {
+			continue
+		}
+
+		p := promotion{
+			PromotedTask: PromotedTask{
+				ProjectName: row.ProjectName,
+				SectionName: row.SectionName,
+				Path:        row.Path,
+				Depth:       row.Depth,
+			},
+		}
+
+		if len(projectCandidates) > 0 {
+			task := bestCandidate(projectCandidates, node.Sections, strategy)
+			p.TaskID = task.ID
+			p.Content = task.Content
+			p.URL = fmt.Sprintf("https://todoist.com/showTask?id=%s", task.ID)
+			p.command = newCommand("item_update", map[string]any{
+				"id":     task.ID,
+				"labels": append(slices.Clone(task.Labels), "next_action"),
+			})
+			satisfied[node.ID] = true
+			satisfied[task.SectionID] = true
+		} else {
+			if section == nil && hasCheckedChildren(node, checkConfig) {
+				continue
+			}
+
+			args := map[string]any{
+				"content":    fmt.Sprintf("Define next action for %s", row.Path),
+				"project_id": node.ID,
+				"labels":     []string{"next_action"},
+			}
+			if section != nil {
+				args["section_id"] = section.ID
+			}
+			p.Content = args["content"].(string)
+			p.Created = true
+			p.URL = row.URL
+			p.command = newTempIDCommand("item_add", args)
+			satisfied[targetID] = true
+		}
+
+		promoted = append(promoted, p)
+	}
+
+	return promoted
+}
+
+// findRowTarget returns the project and, for section rows, the section a zero tasks row was reported for.
+func findRowTarget(tree *ProjectTree, row IncorrectProjectSchema) (*ProjectNode, *Section, bool) {
+	if row.SectionName == "" {
+		node, ok := tree.FindByNameOrPath(row.Path)
+		return node, nil, ok
+	}
+
+	node, ok := tree.FindByNameOrPath(strings.TrimSuffix(row.Path, "/"+row.SectionName))
+	if !ok {
+		return nil, nil, false
+	}
+	for i := range node.Sections {
+		if node.Sections[i].Name == row.SectionName {
+			return node, &node.Sections[i], true
+		}
+	}
+	return nil, nil, false
+}
+
+func hasCheckedChildren(node *ProjectNode, checkConfig NextActionCheckConfig) bool {
+	for _, child := range node.Children {
+		if !child.MatchesAny(checkConfig.ExcludeFromZeroProjectsList) {
+			return true
+		}
+	}
+	return false
+}
+
+// bestCandidate returns the task to promote, tasks must not be empty.
+func bestCandidate(tasks []Task, sections []Section, strategy PromotionStrategy) Task {
+	sectionOrder := map[string]int{}
+	for _, section := range sections {
+		// tasks without section are shown above all sections
+		sectionOrder[section.ID] = section.Order + 1
+	}
+
+	return slices.MinFunc(tasks, func(a, b Task) int {
+		if strategy == PromoteByPriority {
+			if c := cmp.Compare(priorityRank(a.Priority), priorityRank(b.Priority)); c != 0 {
+				return c
+			}
+		}
+		if c := cmp.Compare(sectionOrder[a.SectionID], sectionOrder[b.SectionID]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Order, b.Order)
+	})
+}
+
+// PrettyPromotionOutput lists tasks that became next actions under their project or section.
+func (t *Client) PrettyPromotionOutput(promoted []PromotedTask) string {
+	if len(promoted) == 0 {
+		return ""
+	}
+
+	rows := make([]IncorrectProjectSchema, 0, len(promoted))
+	for _, p := range promoted {
+		row := IncorrectProjectSchema{
+			ProjectName: p.ProjectName,
+			SectionName: p.SectionName,
+			Path:        p.Path,
+			Depth:       p.Depth,
+			URL:         p.URL,
+		}
+		action := "promoted"
+		if p.Created {
+			action = "created"
+		}
+		row.Description = fmt.Sprintf("%s - %s [%s](%s)", row.displayName(), action, telegram.EscapeText(p.Content), p.URL)
+		rows = append(rows, row)
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString("added @next_action to projects without one:\n")
+	writeProjectRows(&builder, rows, func(row IncorrectProjectSchema) string {
+		return row.Description
+	})
+
+	return builder.String()
+}
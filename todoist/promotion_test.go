@@ -0,0 +1,90 @@
+package todoist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPromoteNextActions(t *testing.T) {
+	tests := []struct {
+		name         string
+		strategy     PromotionStrategy
+		tasks        []Task
+		wantPromoted []string
+		wantCreated  bool
+	}{
+		{
+			name:     "by order picks the first task",
+			strategy: PromoteByOrder,
+			tasks: []Task{
+				{ID: "first", ProjectID: "work", Order: 1, Priority: P4},
+				{ID: "urgent", ProjectID: "work", Order: 2, Priority: P1},
+			},
+			wantPromoted: []string{"first"},
+		},
+		{
+			name:     "by priority picks the most urgent task",
+			strategy: PromoteByPriority,
+			tasks: []Task{
+				{ID: "first", ProjectID: "work", Order: 1, Priority: P4},
+				{ID: "urgent", ProjectID: "work", Order: 2, Priority: P1},
+			},
+			wantPromoted: []string{"urgent"},
+		},
+		{
+			name:     "skips subtasks and tasks with a GTD label",
+			strategy: PromoteByOrder,
+			tasks: []Task{
+				{ID: "waiting", ProjectID: "work", Order: 1, Labels: []string{"waiting_for"}},
+				{ID: "parent", ProjectID: "work", Order: 2},
+				{ID: "subtask", ProjectID: "work", ParentID: "parent", Order: 1},
+			},
+			wantPromoted: []string{"parent"},
+		},
+		{
+			name:     "creates a placeholder without candidates",
+			strategy: PromoteByOrder,
+			tasks: []Task{
+				{ID: "someday", ProjectID: "work", Labels: []string{"someday_maybe"}},
+			},
+			wantCreated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &MemoryAPI{
+				Projects: []Project{{ID: "work", Name: "Work"}},
+				Tasks:    slices.Clone(tt.tasks),
+			}
+			client := NewClientWithAPI(api)
+
+			promoted, err := client.PromoteNextActions(NextActionCheckConfig{Limit: 1}, NextActionPromotionConfig{Strategy: tt.strategy})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(promoted) != 1 {
+				t.Fatalf("promoted %d tasks, want 1", len(promoted))
+			}
+			if promoted[0].Created != tt.wantCreated {
+				t.Errorf("created = %v, want %v", promoted[0].Created, tt.wantCreated)
+			}
+
+			nextActions := make([]string, 0)
+			for _, task := range api.Tasks {
+				if task.IsNextAction() {
+					nextActions = append(nextActions, task.ID)
+				}
+			}
+			if tt.wantCreated {
+				if len(nextActions) != 1 || slices.Contains(taskIDs(tt.tasks), nextActions[0]) {
+					t.Errorf("next actions = %v, want one created task", nextActions)
+				}
+				return
+			}
+			if !slices.Equal(nextActions, tt.wantPromoted) {
+				t.Errorf("next actions = %v, want %v", nextActions, tt.wantPromoted)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package todoist
+
+import (
+	"fmt"
+)
+
+type QuickAddResult struct {
+	TaskID      string       `json:"taskID"`
+	ProjectName string       `json:"projectName"`
+	Task        ImportedTask `json:"task"`
+	URL         string       `json:"url"`
+}
+
+// QuickAddTask creates a task from a line with inline `#project`, `@label`, `p1`..`p4` and `due:` tokens.
+// Tasks without a project token go to the Inbox.
+func (t *Client) QuickAddTask(text string) (*QuickAddResult, error) {
+	task, err := parseInlineTokens(text)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := t.getProjectList()
+	project, ok := findInboxProject(projects)
+	if !ok {
+		return nil, fmt.Errorf("did not find Inbox project")
+	}
+	if task.Project != "" {
+		node, ok := NewProjectTree(projects).FindByNameOrPath(task.Project)
+		if !ok {
+			return nil, fmt.Errorf("did not find `%s` project", task.Project)
+		}
+		project = &node.Project
+	}
+
+	commands := importedTaskCommands(task, project.ID, "", "")
+	resp, err := t.doSyncCommands(commands)
+	if err != nil {
+		return nil, err
+	}
+
+	taskID := resp.TempIDMapping[commands[0].TempID]
+	return &QuickAddResult{
+		TaskID:      taskID,
+		ProjectName: project.Name,
+		Task:        task,
+		URL:         fmt.Sprintf("https://todoist.com/showTask?id=%s", taskID),
+	}, nil
+}
@@ -1,24 +1,92 @@
 package todoist
 
 type Project struct {
-	ID   string
-	Name string
-	Url  string
+	ID             string
+	ParentID       string `json:"parent_id"`
+	Name           string
+	Url            string
+	Order          int
+	IsInboxProject bool `json:"is_inbox_project"`
+	// Color is a Todoist colour name such as "berry_red".
+	Color string `json:"color"`
 }
 
 type Task struct {
+	ID          string     `json:"id"`
+	ProjectID   string     `json:"project_id"`
+	SectionID   string     `json:"section_id"`
+	ParentID    string     `json:"parent_id"`
+	Content     string     `json:"content"`
+	Description string     `json:"description"`
+	Labels      []string   `json:"labels"`
+	CreatedAt   TimeParser `json:"created_at"`
+	Priority    Priority   `json:"priority"`
+	Order       int        `json:"order"`
+	Due         *Due       `json:"due"`
+	// AssigneeID and AssignerID are only set on tasks in shared projects.
+	AssigneeID string `json:"assignee_id,omitempty"`
+	AssignerID string `json:"assigner_id,omitempty"`
+}
+
+type Due struct {
+	Date        string `json:"date"`
+	String      string `json:"string"`
+	IsRecurring bool   `json:"is_recurring"`
+	Datetime    string `json:"datetime,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
+}
+
+type Label struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	Order      int    `json:"order"`
+	IsFavorite bool   `json:"is_favorite"`
+}
+
+// Collaborator is a person sharing at least one project with the user.
+type Collaborator struct {
+	ID       string `json:"id"`
+	Name     string `json:"full_name"`
+	Email    string `json:"email"`
+	Timezone string `json:"timezone"`
+}
+
+// User is the owner of the API token.
+type User struct {
+	ID       string `json:"id"`
+	Name     string `json:"full_name"`
+	Email    string `json:"email"`
+	Timezone string `json:"timezone"`
+}
+
+// Comment is a task or project note as returned by the Sync API.
+type Comment struct {
 	ID        string     `json:"id"`
-	ProjectID string     `json:"project_id"`
+	TaskID    string     `json:"item_id,omitempty"`
+	ProjectID string     `json:"project_id,omitempty"`
 	Content   string     `json:"content"`
-	Labels    []string   `json:"labels"`
-	CreatedAt TimeParser `json:"created_at"`
-	Priority  int        `json:"priority"`
+	PostedAt  TimeParser `json:"posted_at"`
+}
+
+// CompletedTask is an item returned by the Sync API `completed/get_all` endpoint.
+type CompletedTask struct {
+	ID          string     `json:"id"`
+	TaskID      string     `json:"task_id"`
+	ProjectID   string     `json:"project_id"`
+	Content     string     `json:"content"`
+	CompletedAt TimeParser `json:"completed_at"`
+	Item        struct {
+		Labels  []string   `json:"labels"`
+		AddedAt TimeParser `json:"added_at"`
+	} `json:"item_object"`
 }
 
 type Command struct {
-	Type string          `json:"type"`
-	Args MoveCommandArgs `json:"args"`
-	Uuid string          `json:"uuid"`
+	Type   string `json:"type"`
+	Args   any    `json:"args"`
+	Uuid   string `json:"uuid"`
+	TempID string `json:"temp_id,omitempty"`
 }
 
 type MoveCommandArgs struct {
@@ -0,0 +1,149 @@
+package todoist
+
+import (
+	"context"
+	"log"
+	"path"
+	"slices"
+	"strings"
+)
+
+type Section struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
+	Order     int    `json:"order"`
+}
+
+func (t *Client) ListSections(ctx context.Context) ([]Section, error) {
+	return t.api.ListSections(ctx)
+}
+
+func (t *Client) getSectionList() []Section {
+	sections, err := t.ListSections(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return sections
+}
+
+// SetSections attaches sections to their projects in display order.
+func (tree *ProjectTree) SetSections(sections []Section) {
+	for _, section := range sections {
+		node, ok := tree.ByID[section.ProjectID]
+		if !ok {
+			continue
+		}
+		node.Sections = append(node.Sections, section)
+	}
+
+	tree.Walk(func(node *ProjectNode) {
+		slices.SortStableFunc(node.Sections, func(a, b Section) int {
+			return a.Order - b.Order
+		})
+	})
+}
+
+// SectionPath returns the section path as used in config, e.g. "Areas/Work/Backlog".
+func (node *ProjectNode) SectionPath(section Section) string {
+	return node.Path + "/" + section.Name
+}
+
+// sectionMatchesAny reports whether a section matches one of the patterns, which are read like in MatchesAny:
+// a section name ("Backlog"), a section path ("Work/Backlog" or "Areas/Work/Backlog"), a path glob ("Work/*")
+// or a subtree pattern of its project ("Work/**").
+func (node *ProjectNode) sectionMatchesAny(section Section, patterns []string) bool {
+	sectionPath := node.SectionPath(section)
+	for _, pattern := range patterns {
+		if pattern == section.Name || pattern == sectionPath || pattern == node.Name+"/"+section.Name {
+			return true
+		}
+		if strings.HasSuffix(pattern, "/**") && node.matches(pattern) {
+			return true
+		}
+		if ok, err := path.Match(pattern, sectionPath); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sectionURL links to the section itself, as search queries cannot select a single section.
+func sectionURL(section Section) string {
+	return "https://app.todoist.com/app/section/" + section.ID
+}
+
+func (c NextActionCheckConfig) sectionLimitFor(node *ProjectNode, section Section) (limit int, ok bool) {
+	if limit, ok := c.SectionLimits[node.SectionPath(section)]; ok {
+		return limit, true
+	}
+	limit, ok = c.SectionLimits[node.Name+"/"+section.Name]
+	return limit, ok
+}
+
+func (c NextActionCheckConfig) usesSections() bool {
+	return len(c.SectionLimits) > 0 || c.CheckZeroPerSection
+}
+
+func countSectionTasks(tasks []Task, sectionID string) int {
+	count := 0
+	for _, task := range tasks {
+		if task.SectionID == sectionID {
+			count++
+		}
+	}
+	return count
+}
+
+func (t *Client) filterSections(node *ProjectNode, nextActionTasks map[string][]Task, config NextActionCheckConfig) []IncorrectProjectSchema {
+	sectionsWithTooManyTasks := make([]IncorrectProjectSchema, 0)
+
+	for _, section := range node.Sections {
+		limit, ok := config.sectionLimitFor(node, section)
+		if !ok {
+			continue
+		}
+
+		tasksCount := countSectionTasks(nextActionTasks[node.ID], section.ID)
+		if tasksCount > limit {
+			sectionsWithTooManyTasks = append(sectionsWithTooManyTasks, IncorrectProjectSchema{
+				ProjectName: node.Name,
+				SectionName: section.Name,
+				Path:        node.SectionPath(section),
+				Depth:       node.Depth + 1,
+				TasksCount:  tasksCount,
+				URL:         sectionURL(section),
+				Limit:       limit,
+				Description: "Section has more active tasks that allowed",
+			})
+		}
+	}
+
+	return sectionsWithTooManyTasks
+}
+
+func (t *Client) findSectionsWithZeroTasks(node *ProjectNode, nextActionTasks map[string][]Task, config NextActionCheckConfig) []IncorrectProjectSchema {
+	sectionsWithZeroTasks := make([]IncorrectProjectSchema, 0)
+
+	for _, section := range node.Sections {
+		if node.sectionMatchesAny(section, config.ExcludeFromZeroProjectsList) {
+			continue
+		}
+
+		if countSectionTasks(nextActionTasks[node.ID], section.ID) == 0 {
+			sectionsWithZeroTasks = append(sectionsWithZeroTasks, IncorrectProjectSchema{
+				ProjectName: node.Name,
+				SectionName: section.Name,
+				Path:        node.SectionPath(section),
+				Depth:       node.Depth + 1,
+				TasksCount:  0,
+				URL:         sectionURL(section),
+				Limit:       0,
+				Description: "no active tasks in this section",
+			})
+		}
+	}
+
+	return sectionsWithZeroTasks
+}
@@ -0,0 +1,66 @@
+package todoist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSectionsWithZeroTasksExclusion(t *testing.T) {
+	projects := []Project{
+		{ID: "areas", Name: "Areas"},
+		{ID: "work", Name: "Work", ParentID: "areas"},
+		{ID: "home", Name: "Home"},
+	}
+	sections := []Section{
+		{ID: "backlog", ProjectID: "work", Name: "Backlog", Order: 1},
+		{ID: "week", ProjectID: "work", Name: "This week", Order: 2},
+		{ID: "garden", ProjectID: "home", Name: "Garden", Order: 1},
+	}
+	sectionIDs := map[string]string{
+		"Areas/Work/Backlog":   "backlog",
+		"Areas/Work/This week": "week",
+		"Home/Garden":          "garden",
+	}
+	tasks := []Task{
+		{ID: "1", ProjectID: "work", Labels: []string{"next_action"}},
+		{ID: "2", ProjectID: "home", Labels: []string{"next_action"}},
+	}
+
+	tests := []struct {
+		name     string
+		excluded []string
+		want     []string
+	}{
+		{name: "nothing excluded", want: []string{"Areas/Work/Backlog", "Areas/Work/This week", "Home/Garden"}},
+		{name: "section name", excluded: []string{"Backlog"}, want: []string{"Areas/Work/This week", "Home/Garden"}},
+		{name: "project name and section", excluded: []string{"Work/Backlog"}, want: []string{"Areas/Work/This week", "Home/Garden"}},
+		{name: "section path", excluded: []string{"Areas/Work/This week"}, want: []string{"Areas/Work/Backlog", "Home/Garden"}},
+		{name: "path glob", excluded: []string{"Home/*"}, want: []string{"Areas/Work/Backlog", "Areas/Work/This week"}},
+		{name: "project subtree", excluded: []string{"Work/**"}, want: []string{"Home/Garden"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClientWithAPI(&MemoryAPI{Projects: projects, Sections: sections, Tasks: tasks})
+
+			_, zero := client.GetProjectsWithTooManyAndZeroTasks(NextActionCheckConfig{
+				Limit:                       5,
+				CheckZeroPerSection:         true,
+				ExcludeFromZeroProjectsList: tt.excluded,
+			})
+
+			got := make([]string, 0)
+			for _, row := range zero {
+				if row.SectionName != "" {
+					got = append(got, row.Path)
+					if row.URL != "https://app.todoist.com/app/section/"+sectionIDs[row.Path] {
+						t.Errorf("%s url = %s", row.Path, row.URL)
+					}
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("zero sections = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
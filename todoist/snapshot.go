@@ -0,0 +1,234 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SnapshotVersion is incremented whenever the snapshot format changes incompatibly.
+const SnapshotVersion = 1
+
+// snapshotCompletedDays is how far back completed tasks are saved, so that diffs can tell completed tasks from deleted ones.
+const snapshotCompletedDays = 30
+
+// Snapshot is a full export of the account.
+type Snapshot struct {
+	Version        int             `json:"version"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	Projects       []Project       `json:"projects"`
+	Sections       []Section       `json:"sections"`
+	Tasks          []Task          `json:"tasks"`
+	CompletedTasks []CompletedTask `json:"completedTasks"`
+	Labels         []Label         `json:"labels"`
+	Comments       []Comment       `json:"comments"`
+}
+
+func (t *Client) TakeSnapshot() Snapshot {
+	now := time.Now().UTC()
+	return Snapshot{
+		Version:        SnapshotVersion,
+		CreatedAt:      now,
+		Projects:       t.getProjectList(),
+		Sections:       t.getSectionList(),
+		Tasks:          t.getTasks(),
+		CompletedTasks: t.getCompletedTasks(now.AddDate(0, 0, -snapshotCompletedDays)),
+		Labels:         t.getLabelList(),
+		Comments:       t.getComments(),
+	}
+}
+
+// SnapshotName returns the name a snapshot is saved under, sortable by creation time.
+func SnapshotName(snapshot Snapshot) string {
+	return fmt.Sprintf("todoist-snapshot-%s.json", snapshot.CreatedAt.UTC().Format("20060102T150405Z"))
+}
+
+func ParseSnapshot(b []byte) (*Snapshot, error) {
+	var snapshot Snapshot
+	err := json.Unmarshal(b, &snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d, expected %d", snapshot.Version, SnapshotVersion)
+	}
+	return &snapshot, nil
+}
+
+func (t *Client) getComments() []Comment {
+	comments, err := t.api.ListComments(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return comments
+}
+
+type SnapshotDiff struct {
+	Created    []Task        `json:"created"`
+	Completed  []Task        `json:"completed"`
+	Deleted    []Task        `json:"deleted"`
+	Moved      []TaskMove    `json:"moved"`
+	Relabelled []TaskRelabel `json:"relabelled"`
+}
+
+type TaskMove struct {
+	Task        Task   `json:"task"`
+	FromProject string `json:"fromProject"`
+	ToProject   string `json:"toProject"`
+	FromSection string `json:"fromSection,omitempty"`
+	ToSection   string `json:"toSection,omitempty"`
+}
+
+type TaskRelabel struct {
+	Task    Task     `json:"task"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// DiffSnapshots lists task changes between an older and a newer snapshot.
+func DiffSnapshots(older, newer *Snapshot) SnapshotDiff {
+	diff := SnapshotDiff{
+		Created:    make([]Task, 0),
+		Completed:  make([]Task, 0),
+		Deleted:    make([]Task, 0),
+		Moved:      make([]TaskMove, 0),
+		Relabelled: make([]TaskRelabel, 0),
+	}
+
+	olderTasks := make(map[string]Task, len(older.Tasks))
+	for _, task := range older.Tasks {
+		olderTasks[task.ID] = task
+	}
+	newerTasks := make(map[string]Task, len(newer.Tasks))
+	for _, task := range newer.Tasks {
+		newerTasks[task.ID] = task
+	}
+	completedTaskIDs := make(map[string]bool, len(newer.CompletedTasks))
+	for _, task := range newer.CompletedTasks {
+		completedTaskIDs[task.TaskID] = true
+	}
+
+	projectNames := snapshotProjectNames(older, newer)
+	sectionNames := snapshotSectionNames(older, newer)
+
+	for _, task := range older.Tasks {
+		newTask, ok := newerTasks[task.ID]
+		if !ok {
+			if completedTaskIDs[task.ID] {
+				diff.Completed = append(diff.Completed, task)
+			} else {
+				diff.Deleted = append(diff.Deleted, task)
+			}
+			continue
+		}
+
+		if task.ProjectID != newTask.ProjectID || task.SectionID != newTask.SectionID {
+			diff.Moved = append(diff.Moved, TaskMove{
+				Task:        newTask,
+				FromProject: projectNames[task.ProjectID],
+				ToProject:   projectNames[newTask.ProjectID],
+				FromSection: sectionNames[task.SectionID],
+				ToSection:   sectionNames[newTask.SectionID],
+			})
+		}
+
+		added, removed := diffLabels(task.Labels, newTask.Labels)
+		if len(added) > 0 || len(removed) > 0 {
+			diff.Relabelled = append(diff.Relabelled, TaskRelabel{
+				Task:    newTask,
+				Added:   added,
+				Removed: removed,
+			})
+		}
+	}
+
+	for _, task := range newer.Tasks {
+		if _, ok := olderTasks[task.ID]; !ok {
+			diff.Created = append(diff.Created, task)
+		}
+	}
+
+	return diff
+}
+
+func snapshotProjectNames(snapshots ...*Snapshot) map[string]string {
+	names := map[string]string{}
+	for _, snapshot := range snapshots {
+		for _, p := range snapshot.Projects {
+			names[p.ID] = p.Name
+		}
+	}
+	return names
+}
+
+func snapshotSectionNames(snapshots ...*Snapshot) map[string]string {
+	names := map[string]string{}
+	for _, snapshot := range snapshots {
+		for _, s := range snapshot.Sections {
+			names[s.ID] = s.Name
+		}
+	}
+	return names
+}
+
+func diffLabels(older, newer []string) (added, removed []string) {
+	for _, label := range newer {
+		if !slices.Contains(older, label) {
+			added = append(added, label)
+		}
+	}
+	for _, label := range older {
+		if !slices.Contains(newer, label) {
+			removed = append(removed, label)
+		}
+	}
+	return added, removed
+}
+
+func PrettyDiffOutput(diff SnapshotDiff) string {
+	builder := strings.Builder{}
+
+	writeTasks := func(title string, tasks []Task) {
+		if len(tasks) == 0 {
+			return
+		}
+		builder.WriteString(fmt.Sprintf("%s (%d):\n", title, len(tasks)))
+		for _, task := range tasks {
+			builder.WriteString(fmt.Sprintf("  %s\n", task.Content))
+		}
+		builder.WriteString("\n")
+	}
+
+	writeTasks("created", diff.Created)
+	writeTasks("completed", diff.Completed)
+	writeTasks("deleted", diff.Deleted)
+
+	if len(diff.Moved) > 0 {
+		builder.WriteString(fmt.Sprintf("moved (%d):\n", len(diff.Moved)))
+		for _, move := range diff.Moved {
+			from, to := move.FromProject, move.ToProject
+			if move.FromSection != "" {
+				from += "/" + move.FromSection
+			}
+			if move.ToSection != "" {
+				to += "/" + move.ToSection
+			}
+			builder.WriteString(fmt.Sprintf("  %s: %s -> %s\n", move.Task.Content, from, to))
+		}
+		builder.WriteString("\n")
+	}
+
+	if len(diff.Relabelled) > 0 {
+		builder.WriteString(fmt.Sprintf("relabelled (%d):\n", len(diff.Relabelled)))
+		for _, relabel := range diff.Relabelled {
+			builder.WriteString(fmt.Sprintf("  %s: +%v -%v\n", relabel.Task.Content, relabel.Added, relabel.Removed))
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,195 @@
+package todoist
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+)
+
+// ProductivityStats summarises completed tasks since a point in time and the current state of open tasks.
+type ProductivityStats struct {
+	Since                 time.Time       `json:"since"`
+	Until                 time.Time       `json:"until"`
+	CompletedCount        int             `json:"completedCount"`
+	CompletionsPerDay     map[string]int  `json:"completionsPerDay"`
+	CompletionsPerWeek    map[string]int  `json:"completionsPerWeek"`
+	CompletionsPerProject map[string]int  `json:"completionsPerProject"`
+	CompletionsPerLabel   map[string]int  `json:"completionsPerLabel"`
+	AverageLeadTimeHours  float64         `json:"averageLeadTimeHours"`
+	Inbox                 InboxThroughput `json:"inbox"`
+	OpenTaskAges          []AgeBucket     `json:"openTaskAges"`
+	// OverdueCount is the number of open tasks due before today in the user's timezone, or before now if due at a time.
+	OverdueCount int `json:"overdueCount"`
+}
+
+// InboxThroughput counts Inbox tasks created and completed in the period.
+// Tasks moved out of the Inbox are not tracked, as that requires the activity log.
+type InboxThroughput struct {
+	Added     int `json:"added"`
+	Completed int `json:"completed"`
+	Open      int `json:"open"`
+}
+
+type AgeBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+var ageBucketLimits = []struct {
+	label string
+	upTo  time.Duration
+}{
+	{"under 1d", 24 * time.Hour},
+	{"1-7d", 7 * 24 * time.Hour},
+	{"7-30d", 30 * 24 * time.Hour},
+	{"30-90d", 90 * 24 * time.Hour},
+	{"over 90d", 0},
+}
+
+func (t *Client) GetProductivityStats(since time.Time) ProductivityStats {
+	projects := t.getProjectList()
+	tasks := t.getTasks()
+	completedTasks := t.getCompletedTasks(since)
+
+	return computeProductivityStats(projects, tasks, completedTasks, since, t.Now())
+}
+
+func computeProductivityStats(projects []Project, openTasks []Task, completedTasks []CompletedTask, since, now time.Time) ProductivityStats {
+	stats := ProductivityStats{
+		Since:                 since,
+		Until:                 now,
+		CompletedCount:        len(completedTasks),
+		CompletionsPerDay:     map[string]int{},
+		CompletionsPerWeek:    map[string]int{},
+		CompletionsPerProject: map[string]int{},
+		CompletionsPerLabel:   map[string]int{},
+	}
+
+	inboxProjectID := ""
+	for _, p := range projects {
+		if p.IsInboxProject {
+			inboxProjectID = p.ID
+		}
+	}
+
+	var leadTimeSum time.Duration
+	leadTimeCount := 0
+	for _, task := range completedTasks {
+		completedAt := task.CompletedAt.In(now.Location())
+		stats.CompletionsPerDay[completedAt.Format(time.DateOnly)]++
+		year, week := completedAt.ISOWeek()
+		stats.CompletionsPerWeek[fmt.Sprintf("%d-W%02d", year, week)]++
+
+		projectName := findProjectName(projects, task.ProjectID)
+		stats.CompletionsPerProject[projectName]++
+
+		for _, label := range task.Item.Labels {
+			stats.CompletionsPerLabel[label]++
+		}
+
+		if !task.Item.AddedAt.IsZero() {
+			leadTimeSum += completedAt.Sub(task.Item.AddedAt.Time)
+			leadTimeCount++
+		}
+
+		if task.ProjectID == inboxProjectID {
+			stats.Inbox.Completed++
+			if task.Item.AddedAt.After(since) {
+				stats.Inbox.Added++
+			}
+		}
+	}
+	if leadTimeCount > 0 {
+		stats.AverageLeadTimeHours = (leadTimeSum / time.Duration(leadTimeCount)).Hours()
+	}
+
+	stats.OpenTaskAges = make([]AgeBucket, len(ageBucketLimits))
+	for i, limit := range ageBucketLimits {
+		stats.OpenTaskAges[i].Label = limit.label
+	}
+	for _, task := range openTasks {
+		if task.IsOverdue(now) {
+			stats.OverdueCount++
+		}
+
+		age := now.Sub(task.CreatedAt.Time)
+		for i, limit := range ageBucketLimits {
+			if limit.upTo == 0 || age < limit.upTo {
+				stats.OpenTaskAges[i].Count++
+				break
+			}
+		}
+
+		if task.ProjectID == inboxProjectID {
+			stats.Inbox.Open++
+			if task.CreatedAt.After(since) {
+				stats.Inbox.Added++
+			}
+		}
+	}
+
+	return stats
+}
+
+func findProjectName(projects []Project, projectID string) string {
+	for _, p := range projects {
+		if p.ID == projectID {
+			return p.Name
+		}
+	}
+	return projectID
+}
+
+// PrettyStatsOutput renders stats as a short Telegram summary.
+func (t *Client) PrettyStatsOutput(stats ProductivityStats) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("completed %d tasks since %s\n", stats.CompletedCount, stats.Since.Format(time.DateOnly)))
+	builder.WriteString(fmt.Sprintf("average lead time: %.1f days\n", stats.AverageLeadTimeHours/24))
+	builder.WriteString(fmt.Sprintf("overdue open tasks: %d\n", stats.OverdueCount))
+
+	if len(stats.CompletionsPerProject) > 0 {
+		builder.WriteString("\n")
+		builder.WriteString("completions per project:\n")
+		for _, name := range sortedKeysByValue(stats.CompletionsPerProject) {
+			builder.WriteString(fmt.Sprintf("%d - %s\n", stats.CompletionsPerProject[name], telegram.EscapeText(name)))
+		}
+	}
+
+	if len(stats.CompletionsPerLabel) > 0 {
+		builder.WriteString("\n")
+		builder.WriteString("completions per label:\n")
+		for _, name := range sortedKeysByValue(stats.CompletionsPerLabel) {
+			builder.WriteString(fmt.Sprintf("%d - @%s\n", stats.CompletionsPerLabel[name], telegram.EscapeText(name)))
+		}
+	}
+
+	builder.WriteString("\n")
+	builder.WriteString(fmt.Sprintf("inbox: %d added, %d completed, %d open\n", stats.Inbox.Added, stats.Inbox.Completed, stats.Inbox.Open))
+
+	builder.WriteString("\n")
+	builder.WriteString("open tasks by age:\n")
+	for _, bucket := range stats.OpenTaskAges {
+		builder.WriteString(fmt.Sprintf("%s: %d\n", bucket.Label, bucket.Count))
+	}
+
+	return builder.String()
+}
+
+func sortedKeysByValue(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		if c := cmp.Compare(m[b], m[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	return keys
+}
@@ -0,0 +1,135 @@
+package todoist
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+)
+
+func completedTask(projectID string, addedAt, completedAt time.Time, labels ...string) CompletedTask {
+	task := CompletedTask{ProjectID: projectID, CompletedAt: TimeParser{completedAt}}
+	task.Item.AddedAt = TimeParser{addedAt}
+	task.Item.Labels = labels
+	return task
+}
+
+func TestComputeProductivityStats(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip(err)
+	}
+	// Monday 2024-03-04 10:00 in Kyiv
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, kyiv)
+	since := now.AddDate(0, 0, -7)
+	projects := []Project{
+		{ID: "inbox", Name: "Inbox", IsInboxProject: true},
+		{ID: "work", Name: "Work"},
+	}
+
+	tests := []struct {
+		name      string
+		completed []CompletedTask
+		open      []Task
+		check     func(t *testing.T, stats ProductivityStats)
+	}{
+		{
+			name: "days and ISO weeks use the user's timezone",
+			completed: []CompletedTask{
+				// Sunday 23:30 UTC is already Monday in Kyiv
+				completedTask("work", time.Time{}, time.Date(2024, 3, 3, 23, 30, 0, 0, time.UTC)),
+				completedTask("work", time.Time{}, time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)),
+			},
+			check: func(t *testing.T, stats ProductivityStats) {
+				wantDays := map[string]int{"2024-03-04": 1, "2024-03-03": 1}
+				if !maps.Equal(stats.CompletionsPerDay, wantDays) {
+					t.Errorf("per day = %v, want %v", stats.CompletionsPerDay, wantDays)
+				}
+				wantWeeks := map[string]int{"2024-W10": 1, "2024-W09": 1}
+				if !maps.Equal(stats.CompletionsPerWeek, wantWeeks) {
+					t.Errorf("per week = %v, want %v", stats.CompletionsPerWeek, wantWeeks)
+				}
+			},
+		},
+		{
+			name: "lead time skips tasks without added time",
+			completed: []CompletedTask{
+				completedTask("work", now.Add(-10*time.Hour), now),
+				completedTask("work", now.Add(-30*time.Hour), now),
+				completedTask("work", time.Time{}, now),
+			},
+			check: func(t *testing.T, stats ProductivityStats) {
+				if stats.AverageLeadTimeHours != 20 {
+					t.Errorf("average lead time = %v hours, want 20", stats.AverageLeadTimeHours)
+				}
+			},
+		},
+		{
+			name: "projects, labels and Inbox throughput",
+			completed: []CompletedTask{
+				completedTask("inbox", now.Add(-time.Hour), now, "errand"),
+				completedTask("work", now.AddDate(0, 0, -30), now, "errand", "deep_work"),
+				completedTask("gone", now.AddDate(0, 0, -30), now),
+			},
+			open: []Task{
+				{ID: "1", ProjectID: "inbox", CreatedAt: TimeParser{now.Add(-time.Hour)}},
+				{ID: "2", ProjectID: "inbox", CreatedAt: TimeParser{now.AddDate(0, 0, -20)}},
+			},
+			check: func(t *testing.T, stats ProductivityStats) {
+				wantProjects := map[string]int{"Inbox": 1, "Work": 1, "gone": 1}
+				if !maps.Equal(stats.CompletionsPerProject, wantProjects) {
+					t.Errorf("per project = %v, want %v", stats.CompletionsPerProject, wantProjects)
+				}
+				wantLabels := map[string]int{"errand": 2, "deep_work": 1}
+				if !maps.Equal(stats.CompletionsPerLabel, wantLabels) {
+					t.Errorf("per label = %v, want %v", stats.CompletionsPerLabel, wantLabels)
+				}
+				want := InboxThroughput{Added: 2, Completed: 1, Open: 2}
+				if stats.Inbox != want {
+					t.Errorf("inbox = %+v, want %+v", stats.Inbox, want)
+				}
+			},
+		},
+		{
+			name: "overdue and age buckets of open tasks",
+			open: []Task{
+				{ID: "yesterday", CreatedAt: TimeParser{now.Add(-2 * time.Hour)}, Due: &Due{Date: "2024-03-03"}},
+				{ID: "today", CreatedAt: TimeParser{now.AddDate(0, 0, -3)}, Due: &Due{Date: "2024-03-04"}},
+				{ID: "an hour ago", CreatedAt: TimeParser{now.AddDate(0, 0, -10)}, Due: &Due{Date: "2024-03-04", Datetime: "2024-03-04T07:00:00Z"}},
+				{ID: "old", CreatedAt: TimeParser{now.AddDate(0, 0, -100)}},
+			},
+			check: func(t *testing.T, stats ProductivityStats) {
+				if stats.OverdueCount != 2 {
+					t.Errorf("overdue = %d, want 2", stats.OverdueCount)
+				}
+				want := []int{1, 1, 1, 0, 1}
+				for i, bucket := range stats.OpenTaskAges {
+					if bucket.Count != want[i] {
+						t.Errorf("bucket %s = %d, want %d", bucket.Label, bucket.Count, want[i])
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, computeProductivityStats(projects, tt.open, tt.completed, since, now))
+		})
+	}
+}
+
+func TestPrettyStatsOutputEscapesNames(t *testing.T) {
+	stats := ProductivityStats{
+		CompletionsPerProject: map[string]int{"Home (old)": 1},
+		CompletionsPerLabel:   map[string]int{"a+b": 1},
+	}
+
+	output := (&Client{}).PrettyStatsOutput(stats)
+
+	for _, want := range []string{`Home \(old\)`, `@a\+b`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %s:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// syncCommandsLimit is the maximum number of commands accepted by a single Sync API request.
+const syncCommandsLimit = 100
+
+type SyncResponse struct {
+	SyncStatus    map[string]json.RawMessage `json:"sync_status"`
+	TempIDMapping map[string]string          `json:"temp_id_mapping"`
+}
+
+func newCommand(commandType string, args any) Command {
+	return Command{
+		Type: commandType,
+		Args: args,
+		Uuid: uuid.New().String(),
+	}
+}
+
+func newTempIDCommand(commandType string, args any) Command {
+	command := newCommand(commandType, args)
+	command.TempID = uuid.New().String()
+	return command
+}
+
+// doSyncCommands sends commands in a single Sync API request and returns an error if any of them failed.
+func (t *Client) doSyncCommands(commands []Command) (*SyncResponse, error) {
+	return t.api.ApplyCommands(context.Background(), commands)
+}
+
+// doSyncCommandsInBatches sends commands split into requests of at most syncCommandsLimit commands.
+// Commands must not reference temp IDs created in another batch.
+func (t *Client) doSyncCommandsInBatches(commands []Command) error {
+	for start := 0; start < len(commands); start += syncCommandsLimit {
+		end := min(start+syncCommandsLimit, len(commands))
+		_, err := t.doSyncCommands(commands[start:end])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package todoist
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectTemplate describes a project with sections and tasks that can be created in one Sync API request.
+type ProjectTemplate struct {
+	Name  string `yaml:"name"`
+	Color string `yaml:"color"`
+	// Parent is the name or path of an existing project to create the project under.
+	Parent   string            `yaml:"parent"`
+	Tasks    []TaskTemplate    `yaml:"tasks"`
+	Sections []SectionTemplate `yaml:"sections"`
+}
+
+type SectionTemplate struct {
+	Name  string         `yaml:"name"`
+	Tasks []TaskTemplate `yaml:"tasks"`
+}
+
+type TaskTemplate struct {
+	Content     string   `yaml:"content"`
+	Description string   `yaml:"description"`
+	Labels      []string `yaml:"labels"`
+	// Priority is written as in the Todoist UI, from "p1" (urgent) to "p4".
+	Priority Priority `yaml:"priority"`
+	// Due is an offset from the template date such as "0d", "3d", "-1d" or "2w".
+	Due      string         `yaml:"due"`
+	Subtasks []TaskTemplate `yaml:"subtasks"`
+}
+
+// TemplateResult lists the commands sent to instantiate a template.
+type TemplateResult struct {
+	ProjectID string    `json:"projectID,omitempty"`
+	Commands  []Command `json:"commands"`
+}
+
+// LoadProjectTemplate reads a YAML template and substitutes `{{.Name}}`-style variables in its string fields.
+// `Date` is always available and holds the template date as YYYY-MM-DD.
+func LoadProjectTemplate(path string, date time.Time, vars map[string]string) (*ProjectTemplate, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseProjectTemplate(string(b), date, vars)
+}
+
+// ParseProjectTemplate parses the YAML before substituting variables, so that values containing `: ` or `#`
+// cannot change the template structure.
+func ParseProjectTemplate(text string, date time.Time, vars map[string]string) (*ProjectTemplate, error) {
+	data := map[string]string{
+		"Date": date.Format(time.DateOnly),
+	}
+	for k, v := range vars {
+		data[k] = v
+	}
+
+	var projectTemplate ProjectTemplate
+	err := yaml.Unmarshal([]byte(text), &projectTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template yaml: %w", err)
+	}
+
+	fields := []*string{&projectTemplate.Name, &projectTemplate.Color, &projectTemplate.Parent}
+	fields = appendTaskTemplateFields(fields, projectTemplate.Tasks)
+	for i := range projectTemplate.Sections {
+		fields = append(fields, &projectTemplate.Sections[i].Name)
+		fields = appendTaskTemplateFields(fields, projectTemplate.Sections[i].Tasks)
+	}
+	for _, field := range fields {
+		*field, err = substituteTemplateVariables(*field, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if projectTemplate.Name == "" {
+		return nil, fmt.Errorf("template has no project name")
+	}
+
+	return &projectTemplate, nil
+}
+
+// appendTaskTemplateFields appends pointers to the string fields of tasks and their subtasks.
+func appendTaskTemplateFields(fields []*string, tasks []TaskTemplate) []*string {
+	for i := range tasks {
+		task := &tasks[i]
+		fields = append(fields, &task.Content, &task.Description, &task.Due)
+		for j := range task.Labels {
+			fields = append(fields, &task.Labels[j])
+		}
+		fields = appendTaskTemplateFields(fields, task.Subtasks)
+	}
+	return fields
+}
+
+func substituteTemplateVariables(text string, data map[string]string) (string, error) {
+	tmpl, err := template.New("project").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to substitute template variables: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// InstantiateTemplate creates the project described by the template with a single Sync API request.
+// Due dates are relative to date.
+func (t *Client) InstantiateTemplate(projectTemplate *ProjectTemplate, date time.Time, dryRun bool) (*TemplateResult, error) {
+	projectArgs := map[string]any{
+		"name": projectTemplate.Name,
+	}
+	if projectTemplate.Color != "" {
+		projectArgs["color"] = projectTemplate.Color
+	}
+	if projectTemplate.Parent != "" {
+		tree := NewProjectTree(t.getProjectList())
+		parent, ok := tree.FindByNameOrPath(projectTemplate.Parent)
+		if !ok {
+			return nil, fmt.Errorf("did not find parent project `%s`", projectTemplate.Parent)
+		}
+		projectArgs["parent_id"] = parent.ID
+	}
+
+	projectCommand := newTempIDCommand("project_add", projectArgs)
+	commands := []Command{projectCommand}
+
+	taskCommands, err := taskTemplateCommands(projectTemplate.Tasks, projectCommand.TempID, "", "", date)
+	if err != nil {
+		return nil, err
+	}
+	commands = append(commands, taskCommands...)
+
+	for _, section := range projectTemplate.Sections {
+		sectionCommand := newTempIDCommand("section_add", map[string]any{
+			"name":       section.Name,
+			"project_id": projectCommand.TempID,
+		})
+		commands = append(commands, sectionCommand)
+
+		taskCommands, err := taskTemplateCommands(section.Tasks, projectCommand.TempID, sectionCommand.TempID, "", date)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, taskCommands...)
+	}
+
+	if dryRun {
+		return &TemplateResult{Commands: commands}, nil
+	}
+
+	resp, err := t.doSyncCommands(commands)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateResult{
+		ProjectID: resp.TempIDMapping[projectCommand.TempID],
+		Commands:  commands,
+	}, nil
+}
+
+func taskTemplateCommands(tasks []TaskTemplate, projectID, sectionID, parentID string, date time.Time) ([]Command, error) {
+	commands := make([]Command, 0, len(tasks))
+
+	for _, task := range tasks {
+		args := map[string]any{
+			"content":    task.Content,
+			"project_id": projectID,
+		}
+		if sectionID != "" {
+			args["section_id"] = sectionID
+		}
+		if parentID != "" {
+			args["parent_id"] = parentID
+		}
+		if task.Description != "" {
+			args["description"] = task.Description
+		}
+		if len(task.Labels) > 0 {
+			args["labels"] = task.Labels
+		}
+		if task.Priority != 0 {
+			args["priority"] = task.Priority.API()
+		}
+		if task.Due != "" {
+			dueDate, err := parseRelativeDate(task.Due, date)
+			if err != nil {
+				return nil, err
+			}
+			args["due"] = map[string]string{"date": dueDate.Format(time.DateOnly)}
+		}
+
+		command := newTempIDCommand("item_add", args)
+		commands = append(commands, command)
+
+		subtaskCommands, err := taskTemplateCommands(task.Subtasks, projectID, sectionID, command.TempID, date)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, subtaskCommands...)
+	}
+
+	return commands, nil
+}
+
+// parseRelativeDate parses offsets like "3d", "+3d", "-1d" or "2w" relative to date.
+func parseRelativeDate(s string, date time.Time) (time.Time, error) {
+	if len(s) < 2 {
+		return time.Time{}, fmt.Errorf("invalid relative date `%s`", s)
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid relative date `%s`: %w", s, err)
+	}
+
+	switch s[len(s)-1] {
+	case 'd':
+		return date.AddDate(0, 0, n), nil
+	case 'w':
+		return date.AddDate(0, 0, 7*n), nil
+	case 'm':
+		return date.AddDate(0, n, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid relative date `%s`, expected a d, w or m suffix", s)
+	}
+}
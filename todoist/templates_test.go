@@ -0,0 +1,45 @@
+package todoist
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseProjectTemplateSubstitutesAfterParsing(t *testing.T) {
+	text := `name: "Release {{.Name}}"
+tasks:
+  - content: Freeze main branch for {{.Name}}
+    labels: [next_action]
+sections:
+  - name: "{{.Date}}"
+    tasks:
+      - content: Tag {{.Name}}
+        subtasks:
+          - content: Announce {{.Name}}
+`
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	name := "v2: hotfix #1"
+
+	projectTemplate, err := ParseProjectTemplate(text, date, map[string]string{"Name": name})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if projectTemplate.Name != "Release "+name {
+		t.Errorf("name = %q", projectTemplate.Name)
+	}
+	if len(projectTemplate.Tasks) != 1 || projectTemplate.Tasks[0].Content != "Freeze main branch for "+name {
+		t.Errorf("tasks = %+v", projectTemplate.Tasks)
+	}
+	if len(projectTemplate.Sections) != 1 || projectTemplate.Sections[0].Name != "2024-03-01" {
+		t.Fatalf("sections = %+v", projectTemplate.Sections)
+	}
+	if subtasks := projectTemplate.Sections[0].Tasks[0].Subtasks; len(subtasks) != 1 || subtasks[0].Content != "Announce "+name {
+		t.Errorf("subtasks = %+v", subtasks)
+	}
+
+	_, err = ParseProjectTemplate(text, date, nil)
+	if err == nil {
+		t.Error("missing variable was substituted")
+	}
+}
@@ -0,0 +1,96 @@
+package todoist
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Location returns the user's timezone, loaded once from Todoist user settings unless set with WithTimezone.
+// UTC is used if the timezone cannot be loaded.
+func (t *Client) Location() *time.Location {
+	t.locationOnce.Do(func() {
+		if t.location != nil {
+			return
+		}
+
+		t.location = time.UTC
+		user, err := t.api.GetCurrentUser(context.Background())
+		if err != nil {
+			log.Printf("failed to load user timezone, using UTC: %v", err)
+			return
+		}
+		if user.Timezone == "" {
+			return
+		}
+		location, err := time.LoadLocation(user.Timezone)
+		if err != nil {
+			log.Printf("unknown user timezone `%s`, using UTC: %v", user.Timezone, err)
+			return
+		}
+		t.location = location
+	})
+	return t.location
+}
+
+// Now returns the current time in the user's timezone.
+func (t *Client) Now() time.Time {
+	return time.Now().In(t.Location())
+}
+
+// DaysAgo returns the start of the calendar day `days` days before today in the user's timezone.
+func (t *Client) DaysAgo(days int) time.Time {
+	return startOfDay(t.Now()).AddDate(0, 0, -days)
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// calendarDaysBetween counts midnights between from and to in the location of to, so that
+// a task created yesterday evening is one day old this morning.
+func calendarDaysBetween(from, to time.Time) int {
+	fromYear, fromMonth, fromDay := from.In(to.Location()).Date()
+	toYear, toMonth, toDay := to.Date()
+	fromDate := time.Date(fromYear, fromMonth, fromDay, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(toYear, toMonth, toDay, 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+// Time returns the due time. Dates without time and floating datetimes are interpreted in loc,
+// datetimes with a fixed timezone keep it.
+func (d *Due) Time(loc *time.Location) (time.Time, error) {
+	if d.Datetime == "" {
+		return time.ParseInLocation(time.DateOnly, d.Date, loc)
+	}
+
+	if d.Timezone != "" {
+		if dueLocation, err := time.LoadLocation(d.Timezone); err == nil {
+			loc = dueLocation
+		}
+	}
+	dueTime, err := time.Parse(time.RFC3339, d.Datetime)
+	if err == nil {
+		return dueTime.In(loc), nil
+	}
+	return time.ParseInLocation("2006-01-02T15:04:05", d.Datetime, loc)
+}
+
+// IsOverdue reports whether the task was due before now. Tasks due on a date without time become overdue
+// the next day in the timezone of now.
+func (task Task) IsOverdue(now time.Time) bool {
+	if task.Due == nil {
+		return false
+	}
+
+	dueTime, err := task.Due.Time(now.Location())
+	if err != nil {
+		log.Printf("invalid due date of task_id=%s: %v", task.ID, err)
+		return false
+	}
+	if task.Due.Datetime == "" {
+		return dueTime.Before(startOfDay(now))
+	}
+	return dueTime.Before(now)
+}
@@ -1,72 +1,138 @@
 package todoist
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"net/url"
 	"slices"
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/valeriikundas/todoist-scripts/telegram"
 )
 
-const PriorityThreshold = 3
-
-type Client struct {
-	apiToken string
+// NextActionCheckConfig configures GetProjectsWithTooManyAndZeroTasks.
+type NextActionCheckConfig struct {
+	// Limit is the default maximum number of @next_action tasks per project.
+	Limit int
+	// ProjectLimits overrides Limit for projects matched by name or path ("Areas/Work").
+	// A limit set on a parent project counts @next_action tasks across its whole subtree.
+	ProjectLimits map[string]int
+	// ExcludeFromZeroProjectsList lists projects skipped by the zero @next_action check.
+	// Entries are matched with ProjectNode.MatchesAny, so "Areas/**" excludes a whole subtree.
+	// Sections are matched the same way by name, path or pattern, e.g. "Backlog" or "Work/Backlog".
+	ExcludeFromZeroProjectsList []string
+	// SectionLimits sets limits on sections keyed by "<project name or path>/<section name>".
+	// Sections without a limit are not checked.
+	SectionLimits map[string]int
+	// CheckZeroPerSection additionally reports every section without @next_action tasks.
+	CheckZeroPerSection bool
+	// GlobalLimit caps @next_action tasks across all projects, 0 disables the cap.
+	GlobalLimit int
+	// AreaLimits caps @next_action tasks in the subtree of parent projects matched by name or path.
+	// Unlike ProjectLimits, an exceeded area cap is reported with the projects contributing the most tasks.
+	AreaLimits map[string]int
+	// Enforcement opts in to demoting excess @next_action tasks before reporting, see EnforceNextActionLimits.
+	Enforcement *WIPEnforcementConfig
+	// Promotion opts in to adding @next_action to projects and sections without one, see PromoteNextActions.
+	Promotion *NextActionPromotionConfig
+	// Lint opts in to reporting badly worded tasks, see LintTasks.
+	Lint *TaskLintConfig
+	// ProjectLint opts in to reporting projects breaking naming and structure conventions, see LintProjects.
+	ProjectLint *ProjectLintConfig
 }
 
-func NewClient(apiToken string) *Client {
-	return &Client{apiToken: apiToken}
+func (c NextActionCheckConfig) limitFor(node *ProjectNode) (limit int, explicit bool) {
+	if limit, ok := c.ProjectLimits[node.Path]; ok {
+		return limit, true
+	}
+	if limit, ok := c.ProjectLimits[node.Name]; ok {
+		return limit, true
+	}
+	return c.Limit, false
 }
 
-func (t *Client) GetProjectsWithTooManyAndZeroTasks(limit int, excludeFromZeroProjectsList []string) (
+func (t *Client) GetProjectsWithTooManyAndZeroTasks(config NextActionCheckConfig) (
 	projectsWithTooManyTasks []IncorrectProjectSchema,
 	projectsWithZeroTasks []IncorrectProjectSchema,
 ) {
-	// TODO: maybe `get all sections` will be more useful
 	projects := t.getProjectList()
 	// fixme: getTasks() fetches too many tasks, filter in some way, can fetch by label straight away or fetch by project
 	tasks := t.getTasks()
-	nextActionTasks := t.mapTasksToProjectAndFilterByLabel(projects, tasks)
+	tree := NewProjectTree(projects)
+	if config.usesSections() {
+		tree.SetSections(t.getSectionList())
+	}
+	nextActionTasks := t.mapTasksToProjectAndFilterByLabel(tree, tasks)
 
-	projectsWithTooManyTasks = t.filterProjects(nextActionTasks, limit)
+	projectsWithTooManyTasks = append(t.filterCaps(tree, nextActionTasks, config), t.filterProjects(tree, nextActionTasks, config)...)
+	projectsWithZeroTasks = t.findProjectsWithZeroTasks(tree, nextActionTasks, config)
 
-	projectsWithZeroTasks = make([]IncorrectProjectSchema, 0, 100)
-	for _, project := range projects {
-		if slices.Contains(excludeFromZeroProjectsList, project.Name) {
-			continue
+	return projectsWithTooManyTasks, projectsWithZeroTasks
+}
+
+func (t *Client) findProjectsWithZeroTasks(tree *ProjectTree, nextActionTasks map[string][]Task, config NextActionCheckConfig) []IncorrectProjectSchema {
+	projectsWithZeroTasks := make([]IncorrectProjectSchema, 0, 100)
+	tree.Walk(func(node *ProjectNode) {
+		if node.MatchesAny(config.ExcludeFromZeroProjectsList) {
+			return
 		}
 
-		_, ok := nextActionTasks[project.Name]
-		if !ok {
+		if countSubtreeTasks(tree, nextActionTasks, node.ID) == 0 {
 			projectsWithZeroTasks = append(projectsWithZeroTasks, IncorrectProjectSchema{
-				ProjectName: project.Name,
+				ProjectName: node.Name,
+				Path:        node.Path,
+				Depth:       node.Depth,
 				TasksCount:  0,
-				URL:         project.Url,
+				URL:         node.Url,
 				Limit:       0,
 				Description: "no active tasks on this project",
 			})
 		}
-	}
 
-	return projectsWithTooManyTasks, projectsWithZeroTasks
+		if config.CheckZeroPerSection {
+			projectsWithZeroTasks = append(projectsWithZeroTasks, t.findSectionsWithZeroTasks(node, nextActionTasks, config)...)
+		}
+	})
+
+	return projectsWithZeroTasks
+}
+
+func countSubtreeTasks(tree *ProjectTree, tasksByProjectID map[string][]Task, projectID string) int {
+	count := 0
+	for _, node := range tree.Subtree(projectID) {
+		count += len(tasksByProjectID[node.ID])
+	}
+	return count
 }
 
 type IncorrectProjectSchema struct {
 	ProjectName string `json:"projectName"`
+	SectionName string `json:"sectionName,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Depth       int    `json:"depth,omitempty"`
 	TasksCount  int    `json:"tasksCount"`
 	URL         string `json:"url"`
 	Limit       int    `json:"limit,omitempty"`
-	Description string `json:"description"`
+	// TaskContent is set on rows about a single task, see LintTasks.
+	TaskContent string `json:"taskContent,omitempty"`
+	// TopProjects is set on global and area cap rows.
+	TopProjects []ProjectTaskCount `json:"topProjects,omitempty"`
+	Description string             `json:"description"`
+}
+
+// displayName is the row name escaped for MarkdownV2 reports.
+func (p IncorrectProjectSchema) displayName() string {
+	if p.SectionName != "" {
+		return "/" + telegram.EscapeText(p.SectionName)
+	}
+	return telegram.EscapeText(p.ProjectName)
 }
 
-// MoveInactiveTasks moves tasks from one project to another that are older and have low priority
-func (t *Client) MoveInactiveTasks(srcProjectName, dstProjectName string, oldThreshold time.Duration, dryRun bool) []Task {
+// MoveInactiveTasks moves tasks from one project to another that were created at least olderThanDays calendar days ago
+// in the user's timezone and have lower priority than protectedPriority
+func (t *Client) MoveInactiveTasks(srcProjectName, dstProjectName string, olderThanDays int, protectedPriority Priority, dryRun bool) []Task {
 	projects := t.getProjectList()
 
 	srcProject, ok := t.findProjectByName(projects, srcProjectName)
@@ -76,8 +142,8 @@ func (t *Client) MoveInactiveTasks(srcProjectName, dstProjectName string, oldThr
 	}
 
 	tasks := t.getProjectTasks(*srcProject)
-	filteredTasks := t.filterTasksByCreationTime(tasks, oldThreshold)
-	filteredTasks = t.filterByPriority(filteredTasks, PriorityThreshold)
+	filteredTasks := t.filterTasksOlderThanDays(tasks, olderThanDays, t.Now())
+	filteredTasks = t.filterByPriority(filteredTasks, protectedPriority)
 
 	dstProject, ok := t.findProjectByName(projects, dstProjectName)
 	if !ok {
@@ -88,23 +154,41 @@ func (t *Client) MoveInactiveTasks(srcProjectName, dstProjectName string, oldThr
 	return filteredTasks
 }
 
-func (c *Client) filterByPriority(tasks []Task, priority int) []Task {
+// filterByPriority keeps tasks that are less urgent than protectedPriority.
+func (c *Client) filterByPriority(tasks []Task, protectedPriority Priority) []Task {
 	filteredTasks := make([]Task, 0)
 	for _, t := range tasks {
-		if t.Priority < priority {
+		if !t.Priority.AtLeast(protectedPriority) {
 			filteredTasks = append(filteredTasks, t)
 		}
 	}
 	return filteredTasks
 }
 
-func (t *Client) getProjectList() []Project {
-	projectsUrl := "https://api.todoist.com/rest/v2/projects"
+func (t *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	return t.api.ListProjects(ctx)
+}
+
+func (t *Client) ListTasks(ctx context.Context) ([]Task, error) {
+	return t.api.ListTasks(ctx)
+}
 
-	b := t.doTodoistRequest(projectsUrl)
+func (t *Client) ListProjectTasks(ctx context.Context, projectID string) ([]Task, error) {
+	return t.api.ListProjectTasks(ctx, projectID)
+}
+
+// FilterTasks lists open tasks matching a Todoist filter query such as "@next_action & #Work".
+func (t *Client) FilterTasks(ctx context.Context, query string) ([]Task, error) {
+	return t.api.FilterTasks(ctx, query)
+}
 
-	var projects []Project
-	err := json.Unmarshal(b, &projects)
+// GetTask returns a task by ID, or an error wrapping ErrNotFound.
+func (t *Client) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	return t.api.GetTask(ctx, taskID)
+}
+
+func (t *Client) getProjectList() []Project {
+	projects, err := t.ListProjects(context.Background())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -113,11 +197,7 @@ func (t *Client) getProjectList() []Project {
 }
 
 func (t *Client) getTasks() []Task {
-	tasksUrl := "https://api.todoist.com/rest/v2/tasks"
-	b := t.doTodoistRequest(tasksUrl)
-
-	var tasks []Task
-	err := json.Unmarshal(b, &tasks)
+	tasks, err := t.ListTasks(context.Background())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -126,12 +206,7 @@ func (t *Client) getTasks() []Task {
 }
 
 func (t *Client) getProjectTasks(project Project) []Task {
-	projectID := project.ID
-	projectTasksUrl := fmt.Sprintf("https://api.todoist.com/rest/v2/tasks?project_id=%s", projectID)
-	b := t.doTodoistRequest(projectTasksUrl)
-
-	var tasks []Task
-	err := json.Unmarshal(b, &tasks)
+	tasks, err := t.ListProjectTasks(context.Background(), project.ID)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -139,117 +214,42 @@ func (t *Client) getProjectTasks(project Project) []Task {
 	return tasks
 }
 
-func (t *Client) moveTasks(tasks []Task, projectID string, dryRun bool) {
-	// FIXME: rewrite with single todoist sync api request
-	for _, task := range tasks {
-		t.moveTask(task.ID, projectID, dryRun)
-	}
-}
-
-func (t *Client) doTodoistRequest(url string) []byte {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	headerKey, headerValue := "Authorization", fmt.Sprintf("Bearer %s", t.apiToken)
-	req.Header.Add(headerKey, headerValue)
-
-	// FIXME: construct client once, do requests then
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	b, err := io.ReadAll(resp.Body)
+func (t *Client) getCompletedTasks(since time.Time) []CompletedTask {
+	completedTasks, err := t.api.ListCompletedTasks(context.Background(), since)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	return b
+	return completedTasks
 }
 
-func (t *Client) doTodoistPostRequest(method string, url string, body io.Reader) []byte {
-	headerKey, headerValue := "Authorization", fmt.Sprintf("Bearer %s", t.apiToken)
-
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		log.Fatal(err)
-	}
-	req.Header.Add(headerKey, headerValue)
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-
-	// FIXME: construct client once, do requests then
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			log.Fatal(err)
+func (t *Client) moveTasks(tasks []Task, projectID string, dryRun bool) {
+	commands := make([]Command, 0, len(tasks))
+	for _, task := range tasks {
+		logMessage := fmt.Sprintf("moving task_id=%s to project_id=%s", task.ID, projectID)
+		if dryRun {
+			log.Printf("dry run: %v", logMessage)
+			continue
 		}
-		log.Fatalf("todoist request failed, url=%s, code=%d, body=%v\n", url, resp.StatusCode, string(body))
-	}
-
-	resultBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Fatal(err)
-	}
+		log.Println(logMessage)
 
-	return resultBytes
-}
-
-func (t *Client) moveTask(taskID string, projectID string, dryRun bool) {
-	logMessage := fmt.Sprintf("moving task_id=%s to project_id=%s\n", taskID, projectID)
-	if dryRun {
-		log.Printf("dry run: %v", logMessage)
-		return
-	}
-	log.Println(logMessage)
-
-	commands := []Command{
-		{
-			Type: "item_move",
-			Args: MoveCommandArgs{
-				Id:        taskID,
-				ProjectID: projectID,
-			},
-			Uuid: uuid.New().String(),
-		},
-	}
-
-	b, err := json.Marshal(commands)
-	if err != nil {
-		log.Fatal(err)
+		commands = append(commands, newCommand("item_move", MoveCommandArgs{
+			Id:        task.ID,
+			ProjectID: projectID,
+		}))
 	}
-	bodyReader := strings.NewReader(fmt.Sprintf("commands=%s", string(b)))
-
-	resp := t.doTodoistPostRequest(http.MethodPost, "https://api.todoist.com/sync/v9/sync", bodyReader)
 
-	var v map[string]interface{}
-	err = json.Unmarshal(resp, &v)
+	err := t.doSyncCommandsInBatches(commands)
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	status, ok := v["sync_status"]
-	if !ok {
-		log.Fatal("todoist request failed")
-	}
-
-	log.Print(status)
 }
 
-func (c *Client) filterTasksByCreationTime(tasks []Task, duration time.Duration) []Task {
+func (c *Client) filterTasksOlderThanDays(tasks []Task, days int, now time.Time) []Task {
 	filteredTasks := make([]Task, 0, len(tasks))
 
-	oldTaskThreshold := time.Now().Add(-duration)
 	for _, t := range tasks {
-		if t.CreatedAt.Compare(oldTaskThreshold) == -1 {
+		if calendarDaysBetween(t.CreatedAt.Time, now) >= days {
 			filteredTasks = append(filteredTasks, t)
 		}
 	}
@@ -265,68 +265,71 @@ func (t *Client) findProjectByName(projects []Project, projectName string) (*Pro
 	return nil, false
 }
 
-func (t *Client) filterProjects(nextActionTasks map[string][]Task, nextActionsTasksLimitPerProject int) []IncorrectProjectSchema {
+func (t *Client) filterProjects(tree *ProjectTree, nextActionTasks map[string][]Task, config NextActionCheckConfig) []IncorrectProjectSchema {
 	projectsWithTooManyTasks := make([]IncorrectProjectSchema, 0, 10)
 
-	for projectName, projectTasks := range nextActionTasks {
-		if len(projectTasks) > nextActionsTasksLimitPerProject {
+	tree.Walk(func(node *ProjectNode) {
+		limit, explicit := config.limitFor(node)
+
+		tasksCount := len(nextActionTasks[node.ID])
+		projectQuery := "#" + node.Name
+		if explicit && len(node.Children) > 0 {
+			tasksCount = countSubtreeTasks(tree, nextActionTasks, node.ID)
+			projectQuery = "##" + node.Name
+		}
+
+		if tasksCount > limit {
 			filterLabel := "next_action"
-			tasksUrl := t.getTasksURL(projectName, &filterLabel)
+			tasksUrl := t.getTasksURL(projectQuery, &filterLabel)
 			projectsWithTooManyTasks = append(projectsWithTooManyTasks, IncorrectProjectSchema{
-				ProjectName: projectName,
-				TasksCount:  len(projectTasks),
+				ProjectName: node.Name,
+				Path:        node.Path,
+				Depth:       node.Depth,
+				TasksCount:  tasksCount,
 				URL:         tasksUrl,
-				Limit:       nextActionsTasksLimitPerProject,
+				Limit:       limit,
 				Description: "Project has more active tasks that allowed",
 			})
 		}
-	}
+
+		projectsWithTooManyTasks = append(projectsWithTooManyTasks, t.filterSections(node, nextActionTasks, config)...)
+	})
 
 	return projectsWithTooManyTasks
 }
 
-func (t *Client) mapTasksToProjectAndFilterByLabel(projects []Project, tasks []Task) map[string][]Task {
+func (t *Client) mapTasksToProjectAndFilterByLabel(tree *ProjectTree, tasks []Task) map[string][]Task {
 	// FIXME: split into 2 steps: 1. filter tasks by label 2. map tasks to project
 	// FIXME: move tasks filter to API query
-	// FIXME: rewrite to map[projectID]Task
 	nextActionTasks := map[string][]Task{}
 	for _, task := range tasks {
-		projectName := t.getProjectNameByProjectID(task.ProjectID, projects)
-		if projectName == nil {
+		if _, ok := tree.ByID[task.ProjectID]; !ok {
 			// FIXME: look into it
-			// log.Printf("nil projectName for projectID=%s taskName=%s\n", task.ProjectID, task.Content)
+			// log.Printf("unknown projectID=%s taskName=%s\n", task.ProjectID, task.Content)
 			continue
 		}
 
-		contains := false
-		for _, l := range task.Labels {
-			if l == "next_action" {
-				contains = true
-				break
-			}
-		}
-		if contains {
-			nextActionTasks[*projectName] = append(nextActionTasks[*projectName], task)
+		if task.IsNextAction() {
+			nextActionTasks[task.ProjectID] = append(nextActionTasks[task.ProjectID], task)
 		}
 	}
 	return nextActionTasks
 }
 
-func (t *Client) getProjectNameByProjectID(projectID string, projects []Project) *string {
-	for _, p := range projects {
-		if p.ID == projectID {
-			return &p.Name
-		}
-	}
-	return nil
+// IsNextAction reports whether a task counts towards @next_action limits.
+func (task Task) IsNextAction() bool {
+	return slices.Contains(task.Labels, "next_action")
 }
 
-func (t *Client) getTasksURL(projectName string, label *string) string {
+// getTasksURL returns a Todoist search URL for a project query such as "#Work" or "##Work" (with subprojects).
+func (t *Client) getTasksURL(projectQuery string, label *string) string {
 	var query string
 	if label == nil {
-		query = fmt.Sprintf("#%s", projectName)
+		query = projectQuery
+	} else if projectQuery == "" {
+		query = "@" + *label
 	} else {
-		query = fmt.Sprintf("@%s&#%s", *label, projectName)
+		query = fmt.Sprintf("@%s&%s", *label, projectQuery)
 	}
 	escapedQuery := url.QueryEscape(query)
 	tasksUrl := fmt.Sprintf("https://todoist.com/app/search/%s", escapedQuery)
@@ -336,20 +339,60 @@ func (t *Client) getTasksURL(projectName string, label *string) string {
 func (t *Client) PrettyOutput(projectsWithTooManyTasks []IncorrectProjectSchema, projectsWithZeroTasks []IncorrectProjectSchema) string {
 	builder := strings.Builder{}
 
-	if len(projectsWithTooManyTasks) > 0 {
-		builder.WriteString("projects with too many @next_action tasks:\n")
-		for _, p := range projectsWithTooManyTasks {
-			builder.WriteString(fmt.Sprintf("%d - [%s](%s)\n", p.TasksCount, p.ProjectName, p.URL))
+	capRows := make([]IncorrectProjectSchema, 0)
+	projectRows := make([]IncorrectProjectSchema, 0, len(projectsWithTooManyTasks))
+	for _, row := range projectsWithTooManyTasks {
+		if row.TopProjects != nil {
+			capRows = append(capRows, row)
+		} else {
+			projectRows = append(projectRows, row)
 		}
 	}
 
+	if len(capRows) > 0 {
+		builder.WriteString("too many @next_action tasks overall:\n")
+		writeCapRows(&builder, capRows)
+		builder.WriteString("\n")
+	}
+
+	if len(projectRows) > 0 {
+		builder.WriteString("projects with too many @next_action tasks:\n")
+		writeProjectRows(&builder, projectRows, func(p IncorrectProjectSchema) string {
+			return fmt.Sprintf("%d - [%s](%s)", p.TasksCount, p.displayName(), p.URL)
+		})
+	}
+
 	if len(projectsWithZeroTasks) > 0 {
 		builder.WriteString("\n")
 		builder.WriteString("projects without @next_action tasks:\n")
-		for _, p := range projectsWithZeroTasks {
-			builder.WriteString(fmt.Sprintf("[%s](%s)\n", p.ProjectName, p.URL))
-		}
+		writeProjectRows(&builder, projectsWithZeroTasks, func(p IncorrectProjectSchema) string {
+			return fmt.Sprintf("[%s](%s)", p.displayName(), p.URL)
+		})
 	}
 
 	return builder.String()
 }
+
+// writeProjectRows writes rows indented by project depth, printing parent names
+// that are not rows themselves so that every row appears under its ancestors.
+func writeProjectRows(builder *strings.Builder, rows []IncorrectProjectSchema, format func(p IncorrectProjectSchema) string) {
+	const indent = "    "
+
+	written := map[string]bool{}
+	for _, row := range rows {
+		if row.Path != "" {
+			parts := strings.Split(row.Path, "/")
+			for i := 0; i < len(parts)-1 && i < row.Depth; i++ {
+				ancestorPath := strings.Join(parts[:i+1], "/")
+				if written[ancestorPath] {
+					continue
+				}
+				written[ancestorPath] = true
+				builder.WriteString(fmt.Sprintf("%s%s\n", strings.Repeat(indent, i), telegram.EscapeText(parts[i])))
+			}
+			written[row.Path] = true
+		}
+
+		builder.WriteString(fmt.Sprintf("%s%s\n", strings.Repeat(indent, row.Depth), format(row)))
+	}
+}
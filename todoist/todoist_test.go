@@ -0,0 +1,139 @@
+package todoist
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func daysAgo(days int) TimeParser {
+	return TimeParser{time.Now().UTC().AddDate(0, 0, -days)}
+}
+
+func taskIDs(tasks []Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func rowPaths(rows []IncorrectProjectSchema) []string {
+	paths := make([]string, 0, len(rows))
+	for _, row := range rows {
+		paths = append(paths, row.Path)
+	}
+	return paths
+}
+
+func TestMoveInactiveTasks(t *testing.T) {
+	tests := []struct {
+		name      string
+		protected Priority
+		dryRun    bool
+		wantMoved []string
+		wantInbox []string
+	}{
+		{
+			name:      "moves old unprotected tasks",
+			protected: DefaultProtectedPriority,
+			wantMoved: []string{"old"},
+			wantInbox: []string{"new", "urgent", "important"},
+		},
+		{
+			name:      "protecting only p1 moves p2 tasks",
+			protected: P1,
+			wantMoved: []string{"old", "important"},
+			wantInbox: []string{"new", "urgent"},
+		},
+		{
+			name:      "dry run moves nothing",
+			protected: DefaultProtectedPriority,
+			dryRun:    true,
+			wantMoved: []string{"old"},
+			wantInbox: []string{"old", "new", "urgent", "important"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &MemoryAPI{
+				Projects: []Project{
+					{ID: "inbox", Name: "Inbox", IsInboxProject: true},
+					{ID: "archive", Name: "inbox_archive"},
+				},
+				Tasks: []Task{
+					{ID: "old", ProjectID: "inbox", Priority: P4, CreatedAt: daysAgo(5)},
+					{ID: "new", ProjectID: "inbox", Priority: P4, CreatedAt: daysAgo(1)},
+					{ID: "urgent", ProjectID: "inbox", Priority: P1, CreatedAt: daysAgo(5)},
+					{ID: "important", ProjectID: "inbox", Priority: P2, CreatedAt: daysAgo(5)},
+				},
+			}
+			client := NewClientWithAPI(api)
+
+			moved := client.MoveInactiveTasks("Inbox", "inbox_archive", 3, tt.protected, tt.dryRun)
+
+			if got := taskIDs(moved); !slices.Equal(got, tt.wantMoved) {
+				t.Errorf("moved = %v, want %v", got, tt.wantMoved)
+			}
+			inbox := slices.DeleteFunc(slices.Clone(api.Tasks), func(task Task) bool { return task.ProjectID != "inbox" })
+			if got := taskIDs(inbox); !slices.Equal(got, tt.wantInbox) {
+				t.Errorf("inbox = %v, want %v", got, tt.wantInbox)
+			}
+		})
+	}
+}
+
+func TestGetProjectsWithTooManyAndZeroTasks(t *testing.T) {
+	projects := []Project{
+		{ID: "work", Name: "Work"},
+		{ID: "home", Name: "Home"},
+		{ID: "areas", Name: "Areas"},
+		{ID: "health", Name: "Health", ParentID: "areas"},
+	}
+	tasks := []Task{
+		{ID: "1", ProjectID: "work", Labels: []string{"next_action"}},
+		{ID: "2", ProjectID: "work", Labels: []string{"next_action"}},
+		{ID: "3", ProjectID: "health", Labels: []string{"next_action"}},
+		{ID: "4", ProjectID: "home", Labels: []string{"someday_maybe"}},
+	}
+
+	tests := []struct {
+		name        string
+		config      NextActionCheckConfig
+		wantTooMany []string
+		wantZero    []string
+	}{
+		{
+			name:        "default limit",
+			config:      NextActionCheckConfig{Limit: 1},
+			wantTooMany: []string{"Work"},
+			wantZero:    []string{"Home"},
+		},
+		{
+			name:     "project limit overrides default",
+			config:   NextActionCheckConfig{Limit: 1, ProjectLimits: map[string]int{"Work": 2}},
+			wantZero: []string{"Home"},
+		},
+		{
+			name:        "excluded projects are not reported as zero",
+			config:      NextActionCheckConfig{Limit: 1, ExcludeFromZeroProjectsList: []string{"Home"}},
+			wantTooMany: []string{"Work"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClientWithAPI(&MemoryAPI{Projects: projects, Tasks: tasks})
+
+			tooMany, zero := client.GetProjectsWithTooManyAndZeroTasks(tt.config)
+
+			if got := rowPaths(tooMany); !slices.Equal(got, tt.wantTooMany) {
+				t.Errorf("too many = %v, want %v", got, tt.wantTooMany)
+			}
+			if got := rowPaths(zero); !slices.Equal(got, tt.wantZero) {
+				t.Errorf("zero = %v, want %v", got, tt.wantZero)
+			}
+		})
+	}
+}
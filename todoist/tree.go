@@ -0,0 +1,145 @@
+package todoist
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// ProjectNode is a project placed in the project hierarchy.
+type ProjectNode struct {
+	Project
+	Parent   *ProjectNode
+	Children []*ProjectNode
+	Sections []Section
+	Depth    int
+	// Path is the slash-separated list of project names from the root, e.g. "Areas/Work".
+	Path string
+}
+
+// ProjectTree is the project hierarchy built from `parent_id` links.
+type ProjectTree struct {
+	Roots []*ProjectNode
+	ByID  map[string]*ProjectNode
+}
+
+func NewProjectTree(projects []Project) *ProjectTree {
+	tree := &ProjectTree{
+		Roots: make([]*ProjectNode, 0),
+		ByID:  make(map[string]*ProjectNode, len(projects)),
+	}
+
+	for _, p := range projects {
+		tree.ByID[p.ID] = &ProjectNode{Project: p}
+	}
+
+	for _, p := range projects {
+		node := tree.ByID[p.ID]
+		parent, ok := tree.ByID[p.ParentID]
+		if p.ParentID == "" || !ok {
+			tree.Roots = append(tree.Roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	sortNodes(tree.Roots)
+	tree.Walk(func(node *ProjectNode) {
+		sortNodes(node.Children)
+		if node.Parent == nil {
+			node.Path = node.Name
+			return
+		}
+		node.Depth = node.Parent.Depth + 1
+		node.Path = node.Parent.Path + "/" + node.Name
+	})
+
+	return tree
+}
+
+func sortNodes(nodes []*ProjectNode) {
+	slices.SortStableFunc(nodes, func(a, b *ProjectNode) int {
+		return a.Order - b.Order
+	})
+}
+
+// Walk visits all nodes depth-first in Todoist display order, parents before children.
+func (tree *ProjectTree) Walk(f func(node *ProjectNode)) {
+	var walk func(nodes []*ProjectNode)
+	walk = func(nodes []*ProjectNode) {
+		for _, node := range nodes {
+			f(node)
+			walk(node.Children)
+		}
+	}
+	walk(tree.Roots)
+}
+
+// Subtree returns the node with the given project ID and all of its descendants.
+func (tree *ProjectTree) Subtree(projectID string) []*ProjectNode {
+	root, ok := tree.ByID[projectID]
+	if !ok {
+		return nil
+	}
+
+	nodes := []*ProjectNode{root}
+	for i := 0; i < len(nodes); i++ {
+		nodes = append(nodes, nodes[i].Children...)
+	}
+	return nodes
+}
+
+// FindByNameOrPath returns the first project whose path or name equals the given value.
+// Paths take precedence, so "Work/Admin" can be told apart from "Home/Admin".
+func (tree *ProjectTree) FindByNameOrPath(nameOrPath string) (*ProjectNode, bool) {
+	var byName *ProjectNode
+	var found *ProjectNode
+	tree.Walk(func(node *ProjectNode) {
+		if found != nil {
+			return
+		}
+		if node.Path == nameOrPath {
+			found = node
+		}
+		if byName == nil && node.Name == nameOrPath {
+			byName = node
+		}
+	})
+	if found != nil {
+		return found, true
+	}
+	return byName, byName != nil
+}
+
+// MatchesAny reports whether the node matches one of the patterns.
+//
+// A pattern is either a plain project name, a project path ("Areas/Work"),
+// a path glob ("Areas/*"), or a subtree pattern ("Areas/**") which matches the
+// project itself and all of its descendants.
+func (node *ProjectNode) MatchesAny(patterns []string) bool {
+	for _, pattern := range patterns {
+		if node.matches(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func (node *ProjectNode) matches(pattern string) bool {
+	if pattern == node.Name || pattern == node.Path {
+		return true
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		for n := node; n != nil; n = n.Parent {
+			if n.Path == prefix || (!strings.Contains(prefix, "/") && n.Name == prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	ok, err := path.Match(pattern, node.Path)
+	return err == nil && ok
+}
@@ -8,11 +8,28 @@ type TimeParser struct {
 	time.Time
 }
 
+const todoistTimeLayout = `"2006-01-02T15:04:05.000000Z"`
+
 func (tp *TimeParser) UnmarshalJSON(b []byte) (err error) {
-	time, err := time.Parse(`"2006-01-02T15:04:05.000000Z"`, string(b))
+	if string(b) == "null" {
+		return nil
+	}
+	parsed, err := time.Parse(todoistTimeLayout, string(b))
+	if err != nil {
+		// the unified API does not always pad fractional seconds
+		parsed, err = time.Parse(`"`+time.RFC3339Nano+`"`, string(b))
+	}
 	if err != nil {
 		return err
 	}
-	tp.Time = time
+	tp.Time = parsed
 	return
 }
+
+// MarshalJSON writes the same format UnmarshalJSON reads, so saved tasks can be loaded back.
+func (tp TimeParser) MarshalJSON() ([]byte, error) {
+	if tp.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(tp.UTC().Format(todoistTimeLayout)), nil
+}
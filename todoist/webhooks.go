@@ -0,0 +1,183 @@
+package todoist
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	WebhookItemAdded     = "item:added"
+	WebhookItemUpdated   = "item:updated"
+	WebhookItemCompleted = "item:completed"
+	WebhookNoteAdded     = "note:added"
+)
+
+// WebhookEvent is the payload Todoist posts to a webhook URL.
+type WebhookEvent struct {
+	EventName   string          `json:"event_name"`
+	UserID      string          `json:"user_id"`
+	EventData   json.RawMessage `json:"event_data"`
+	TriggeredAt TimeParser      `json:"triggered_at"`
+	Version     string          `json:"version"`
+	// EventDataExtra is only sent with item:updated.
+	EventDataExtra *struct {
+		OldItem      *Task  `json:"old_item"`
+		UpdateIntent string `json:"update_intent"`
+	} `json:"event_data_extra"`
+}
+
+// VerifyWebhookSignature checks the `X-Todoist-Hmac-SHA256` header, which is a base64 encoded
+// HMAC-SHA256 of the request body keyed with the app's client secret.
+func VerifyWebhookSignature(body []byte, signature, clientSecret string) bool {
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	err := json.Unmarshal(body, &event)
+	if err != nil {
+		return nil, err
+	}
+	if event.EventName == "" {
+		return nil, fmt.Errorf("webhook event without event_name")
+	}
+	return &event, nil
+}
+
+// WebhookHandlers receive typed webhook events. Nil handlers are skipped.
+type WebhookHandlers struct {
+	OnItemAdded     func(ctx context.Context, task Task) error
+	OnItemUpdated   func(ctx context.Context, task Task, oldTask *Task) error
+	OnItemCompleted func(ctx context.Context, task Task) error
+	OnNoteAdded     func(ctx context.Context, comment Comment) error
+}
+
+// Dispatch decodes event data and calls the handler for the event. Events without a handler are ignored.
+func (h WebhookHandlers) Dispatch(ctx context.Context, event WebhookEvent) error {
+	switch event.EventName {
+	case WebhookItemAdded, WebhookItemUpdated, WebhookItemCompleted:
+		var task Task
+		err := json.Unmarshal(event.EventData, &task)
+		if err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", event.EventName, err)
+		}
+
+		switch {
+		case event.EventName == WebhookItemAdded && h.OnItemAdded != nil:
+			return h.OnItemAdded(ctx, task)
+		case event.EventName == WebhookItemUpdated && h.OnItemUpdated != nil:
+			var oldTask *Task
+			if event.EventDataExtra != nil {
+				oldTask = event.EventDataExtra.OldItem
+			}
+			return h.OnItemUpdated(ctx, task, oldTask)
+		case event.EventName == WebhookItemCompleted && h.OnItemCompleted != nil:
+			return h.OnItemCompleted(ctx, task)
+		}
+
+	case WebhookNoteAdded:
+		if h.OnNoteAdded == nil {
+			return nil
+		}
+		var comment Comment
+		err := json.Unmarshal(event.EventData, &comment)
+		if err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", event.EventName, err)
+		}
+		return h.OnNoteAdded(ctx, comment)
+
+	default:
+		log.Printf("ignoring webhook event %s", event.EventName)
+	}
+
+	return nil
+}
+
+// DeliveryDeduplicator remembers `X-Todoist-Delivery-ID` values, as Todoist retries deliveries
+// that were not acknowledged in time. It only sees deliveries received by the same process.
+type DeliveryDeduplicator struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	seen map[string]time.Time
+}
+
+func NewDeliveryDeduplicator(ttl time.Duration) *DeliveryDeduplicator {
+	return &DeliveryDeduplicator{
+		ttl:  ttl,
+		seen: map[string]time.Time{},
+	}
+}
+
+// Seen records the delivery and reports whether it was already received within the TTL.
+func (d *DeliveryDeduplicator) Seen(deliveryID string, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id, receivedAt := range d.seen {
+		if now.Sub(receivedAt) > d.ttl {
+			delete(d.seen, id)
+		}
+	}
+
+	if _, ok := d.seen[deliveryID]; ok {
+		return true
+	}
+	d.seen[deliveryID] = now
+	return false
+}
+
+// Forget removes a delivery, so that a retry of a delivery that failed to be handled is not skipped.
+func (d *DeliveryDeduplicator) Forget(deliveryID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.seen, deliveryID)
+}
+
+// GetNextActionLimitViolations returns the too many @next_action rows that involve the project:
+// the project itself, its sections and ancestors with a subtree limit.
+func (t *Client) GetNextActionLimitViolations(projectID string, config NextActionCheckConfig) []IncorrectProjectSchema {
+	tree := NewProjectTree(t.getProjectList())
+	node, ok := tree.ByID[projectID]
+	if !ok {
+		return nil
+	}
+	if config.usesSections() {
+		tree.SetSections(t.getSectionList())
+	}
+	nextActionTasks := t.mapTasksToProjectAndFilterByLabel(tree, t.getTasks())
+
+	violations := make([]IncorrectProjectSchema, 0)
+	for _, row := range t.filterCaps(tree, nextActionTasks, config) {
+		if row.Path == "" || row.Path == node.Path || strings.HasPrefix(node.Path, row.Path+"/") {
+			violations = append(violations, row)
+		}
+	}
+	for _, row := range t.filterProjects(tree, nextActionTasks, config) {
+		if row.SectionName != "" {
+			if row.Path == node.SectionPath(Section{Name: row.SectionName}) {
+				violations = append(violations, row)
+			}
+			continue
+		}
+		if row.Path == node.Path || strings.HasPrefix(node.Path, row.Path+"/") {
+			violations = append(violations, row)
+		}
+	}
+	return violations
+}
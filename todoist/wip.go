@@ -0,0 +1,273 @@
+package todoist
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
+)
+
+// TaskOrdering is a criterion for which @next_action tasks are kept when a project is over its limit.
+type TaskOrdering string
+
+const (
+	// OrderByPriority keeps more urgent tasks first.
+	OrderByPriority TaskOrdering = "priority"
+	// OrderByDue keeps tasks with earlier due dates first, tasks without due date last.
+	OrderByDue TaskOrdering = "due"
+	// OrderByAge keeps older tasks first.
+	OrderByAge TaskOrdering = "age"
+)
+
+var DefaultTaskOrdering = []TaskOrdering{OrderByPriority, OrderByDue, OrderByAge}
+
+// ParseTaskOrdering parses a comma separated list such as "priority,due,age".
+func ParseTaskOrdering(s string) ([]TaskOrdering, error) {
+	ordering := make([]TaskOrdering, 0)
+	for _, part := range strings.Split(s, ",") {
+		o := TaskOrdering(strings.TrimSpace(part))
+		switch o {
+		case OrderByPriority, OrderByDue, OrderByAge:
+			ordering = append(ordering, o)
+		default:
+			return nil, fmt.Errorf("invalid task ordering `%s`, expected one of priority, due, age", part)
+		}
+	}
+	return ordering, nil
+}
+
+// WIPEnforcementConfig configures EnforceNextActionLimits.
+type WIPEnforcementConfig struct {
+	// Ordering decides which tasks are kept, compared criterion by criterion.
+	Ordering []TaskOrdering
+	// DemoteLabel replaces @next_action on demoted tasks, e.g. "someday_maybe" or "backlog".
+	DemoteLabel string
+	DryRun      bool
+}
+
+// ParseWIPEnforcementConfig builds the config from a comma separated ordering and a label.
+// An empty ordering disables enforcement and returns nil, an empty label defaults to someday_maybe.
+func ParseWIPEnforcementConfig(ordering string, demoteLabel string) (*WIPEnforcementConfig, error) {
+	if ordering == "" {
+		return nil, nil
+	}
+
+	taskOrdering, err := ParseTaskOrdering(ordering)
+	if err != nil {
+		return nil, err
+	}
+	if demoteLabel == "" {
+		demoteLabel = "someday_maybe"
+	}
+
+	return &WIPEnforcementConfig{
+		Ordering:    taskOrdering,
+		DemoteLabel: demoteLabel,
+	}, nil
+}
+
+// DemotedTask is a task that lost @next_action because its project or section was over the limit.
+type DemotedTask struct {
+	TaskID      string `json:"taskID"`
+	Content     string `json:"content"`
+	ProjectName string `json:"projectName"`
+	SectionName string `json:"sectionName,omitempty"`
+	Path        string `json:"path"`
+	Limit       int    `json:"limit"`
+	URL         string `json:"url"`
+}
+
+// EnforceNextActionLimits demotes the excess @next_action tasks of every project and section that is over its limit,
+// keeping the first tasks according to enforceConfig.Ordering.
+func (t *Client) EnforceNextActionLimits(checkConfig NextActionCheckConfig, enforceConfig WIPEnforcementConfig) ([]DemotedTask, error) {
+	tree := NewProjectTree(t.getProjectList())
+	if checkConfig.usesSections() {
+		tree.SetSections(t.getSectionList())
+	}
+	nextActionTasks := t.mapTasksToProjectAndFilterByLabel(tree, t.getTasks())
+
+	demoted := t.findExcessNextActions(tree, nextActionTasks, checkConfig, enforceConfig, t.Now())
+
+	commands := make([]Command, 0, len(demoted))
+	for _, d := range demoted {
+		commands = append(commands, newCommand("item_update", map[string]any{
+			"id":     d.task.ID,
+			"labels": demotedLabels(d.task.Labels, enforceConfig.DemoteLabel),
+		}))
+	}
+
+	result := make([]DemotedTask, 0, len(demoted))
+	for _, d := range demoted {
+		result = append(result, d.DemotedTask)
+	}
+
+	if enforceConfig.DryRun {
+		return result, nil
+	}
+	return result, t.doSyncCommandsInBatches(commands)
+}
+
+type demotion struct {
+	DemotedTask
+	task Task
+}
+
+func (t *Client) findExcessNextActions(
+	tree *ProjectTree,
+	nextActionTasks map[string][]Task,
+	checkConfig NextActionCheckConfig,
+	enforceConfig WIPEnforcementConfig,
+	now time.Time,
+) []demotion {
+	demoted := make([]demotion, 0)
+	demotedIDs := map[string]bool{}
+
+	// demoteExcess keeps limit tasks that were not demoted by an earlier row and demotes the rest
+	demoteExcess := func(row IncorrectProjectSchema, tasks []Task) {
+		kept := slices.DeleteFunc(slices.Clone(tasks), func(task Task) bool { return demotedIDs[task.ID] })
+		if len(kept) <= row.Limit {
+			return
+		}
+
+		sortTasks(kept, enforceConfig.Ordering, now)
+		for _, task := range kept[row.Limit:] {
+			demotedIDs[task.ID] = true
+			demoted = append(demoted, demotion{
+				DemotedTask: DemotedTask{
+					TaskID:      task.ID,
+					Content:     task.Content,
+					ProjectName: row.ProjectName,
+					SectionName: row.SectionName,
+					Path:        row.Path,
+					Limit:       row.Limit,
+					URL:         fmt.Sprintf("https://todoist.com/showTask?id=%s", task.ID),
+				},
+				task: task,
+			})
+		}
+	}
+
+	for _, row := range t.filterProjects(tree, nextActionTasks, checkConfig) {
+		if row.SectionName != "" {
+			node, ok := tree.FindByNameOrPath(strings.TrimSuffix(row.Path, "/"+row.SectionName))
+			if !ok {
+				continue
+			}
+			for _, section := range node.Sections {
+				if section.Name == row.SectionName {
+					demoteExcess(row, sectionTasks(nextActionTasks[node.ID], section.ID))
+				}
+			}
+			continue
+		}
+
+		node, ok := tree.FindByNameOrPath(row.Path)
+		if !ok {
+			continue
+		}
+		tasks := nextActionTasks[node.ID]
+		if _, explicit := checkConfig.limitFor(node); explicit && len(node.Children) > 0 {
+			tasks = make([]Task, 0)
+			for _, n := range tree.Subtree(node.ID) {
+				tasks = append(tasks, nextActionTasks[n.ID]...)
+			}
+		}
+		demoteExcess(row, tasks)
+	}
+
+	return demoted
+}
+
+func sectionTasks(tasks []Task, sectionID string) []Task {
+	result := make([]Task, 0)
+	for _, task := range tasks {
+		if task.SectionID == sectionID {
+			result = append(result, task)
+		}
+	}
+	return result
+}
+
+// sortTasks sorts tasks so that the ones to keep come first.
+func sortTasks(tasks []Task, ordering []TaskOrdering, now time.Time) {
+	slices.SortStableFunc(tasks, func(a, b Task) int {
+		for _, o := range ordering {
+			var c int
+			switch o {
+			case OrderByPriority:
+				c = cmp.Compare(priorityRank(a.Priority), priorityRank(b.Priority))
+			case OrderByDue:
+				c = compareDue(a, b, now.Location())
+			case OrderByAge:
+				c = a.CreatedAt.Compare(b.CreatedAt.Time)
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// priorityRank puts tasks without priority after p4 ones.
+func priorityRank(p Priority) int {
+	if p == 0 {
+		return int(P4) + 1
+	}
+	return int(p)
+}
+
+func compareDue(a, b Task, loc *time.Location) int {
+	aDue, aErr := dueTime(a, loc)
+	bDue, bErr := dueTime(b, loc)
+	switch {
+	case aErr != nil && bErr != nil:
+		return 0
+	case aErr != nil:
+		return 1
+	case bErr != nil:
+		return -1
+	}
+	return aDue.Compare(bDue)
+}
+
+func dueTime(task Task, loc *time.Location) (time.Time, error) {
+	if task.Due == nil {
+		return time.Time{}, fmt.Errorf("task has no due date")
+	}
+	return task.Due.Time(loc)
+}
+
+func demotedLabels(labels []string, demoteLabel string) []string {
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label != "next_action" && label != demoteLabel {
+			result = append(result, label)
+		}
+	}
+	return append(result, demoteLabel)
+}
+
+// PrettyDemotionOutput lists demoted tasks under the project or section that was over its limit.
+func (t *Client) PrettyDemotionOutput(demoted []DemotedTask, demoteLabel string) string {
+	if len(demoted) == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("moved %d tasks from @next_action to @%s:\n", len(demoted), telegram.EscapeText(demoteLabel)))
+	lastPath := ""
+	for _, d := range demoted {
+		if d.Path != lastPath {
+			builder.WriteString(fmt.Sprintf("%s, limit %d:\n", telegram.EscapeText(d.Path), d.Limit))
+			lastPath = d.Path
+		}
+		builder.WriteString(fmt.Sprintf("    [%s](%s)\n", telegram.EscapeText(d.Content), d.URL))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,71 @@
+package todoist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEnforceNextActionLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		dryRun      bool
+		wantDemoted []string
+		wantLabels  map[string][]string
+	}{
+		{
+			name:        "demotes the least urgent tasks",
+			wantDemoted: []string{"p3", "p4"},
+			wantLabels: map[string][]string{
+				"p1": {"next_action"},
+				"p3": {"errand", "someday_maybe"},
+				"p4": {"someday_maybe"},
+			},
+		},
+		{
+			name:        "dry run keeps labels",
+			dryRun:      true,
+			wantDemoted: []string{"p3", "p4"},
+			wantLabels: map[string][]string{
+				"p1": {"next_action"},
+				"p3": {"next_action", "errand"},
+				"p4": {"next_action"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &MemoryAPI{
+				Projects: []Project{{ID: "work", Name: "Work"}},
+				Tasks: []Task{
+					{ID: "p4", ProjectID: "work", Priority: P4, Labels: []string{"next_action"}},
+					{ID: "p1", ProjectID: "work", Priority: P1, Labels: []string{"next_action"}},
+					{ID: "p3", ProjectID: "work", Priority: P3, Labels: []string{"next_action", "errand"}},
+				},
+			}
+			client := NewClientWithAPI(api)
+
+			demoted, err := client.EnforceNextActionLimits(
+				NextActionCheckConfig{Limit: 1},
+				WIPEnforcementConfig{Ordering: []TaskOrdering{OrderByPriority}, DemoteLabel: "someday_maybe", DryRun: tt.dryRun},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]string, 0, len(demoted))
+			for _, d := range demoted {
+				ids = append(ids, d.TaskID)
+			}
+			if !slices.Equal(ids, tt.wantDemoted) {
+				t.Errorf("demoted = %v, want %v", ids, tt.wantDemoted)
+			}
+			for id, want := range tt.wantLabels {
+				task, _ := api.findTask(id)
+				if !slices.Equal(task.Labels, want) {
+					t.Errorf("task %s labels = %v, want %v", id, task.Labels, want)
+				}
+			}
+		})
+	}
+}
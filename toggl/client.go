@@ -0,0 +1,98 @@
+package toggl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const createdWith = "gtd-scripts"
+
+type Toggl struct {
+	apiToken   string
+	httpClient *http.Client
+	baseUrl    string
+	cache      *nameCache
+}
+
+type Option func(t *Toggl)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(t *Toggl) {
+		t.httpClient = httpClient
+	}
+}
+
+// WithBaseURL replaces `https://api.track.toggl.com`, e.g. with a test server.
+func WithBaseURL(baseUrl string) Option {
+	return func(t *Toggl) {
+		t.baseUrl = baseUrl
+	}
+}
+
+func NewToggl(apiToken string, options ...Option) Toggl {
+	t := Toggl{
+		apiToken:   apiToken,
+		httpClient: &http.Client{},
+		baseUrl:    "https://api.track.toggl.com",
+		cache:      sharedNameCache,
+	}
+	for _, option := range options {
+		option(&t)
+	}
+	return t
+}
+
+// do sends a request to the v9 API at path, e.g. "/me", encoding body as JSON unless it is nil
+// and decoding the response into v unless it is nil or the response is empty.
+func (t *Toggl) do(ctx context.Context, method string, path string, body any, v any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, t.baseUrl+"/api/v9"+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	req.SetBasicAuth(t.apiToken, "api_token")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{
+			Method:     method,
+			URL:        req.URL.String(),
+			StatusCode: resp.StatusCode,
+			Body:       string(b),
+		}
+	}
+
+	if v == nil || len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return fmt.Errorf("error decoding toggl response from %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package toggl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func newTestToggl(t *testing.T, handler http.HandlerFunc) *Toggl {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	toggl := NewToggl("token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	return &toggl
+}
+
+func TestDoReturnsAPIError(t *testing.T) {
+	toggl := newTestToggl(t, func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "token" {
+			t.Errorf("basic auth user = %q, want token", user)
+		}
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "Incorrect username and/or password")
+	})
+
+	_, err := toggl.GetMe(context.Background())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusForbidden || apiErr.Method != http.MethodGet || apiErr.Body != "Incorrect username and/or password" {
+		t.Errorf("api error = %+v", apiErr)
+	}
+}
+
+func TestGetCurrentTimeEntry(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		wantID int64
+	}{
+		{name: "no running entry", body: "null"},
+		{name: "empty body", body: ""},
+		{name: "running entry", body: `{"id": 42, "duration": -1, "description": "Write report"}`, wantID: 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toggl := newTestToggl(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v9/me/time_entries/current" {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				fmt.Fprint(w, tt.body)
+			})
+
+			entry, err := toggl.GetCurrentTimeEntry(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantID == 0 {
+				if entry != nil {
+					t.Errorf("entry = %+v, want nil", entry)
+				}
+				return
+			}
+			if entry == nil || entry.ID != tt.wantID || !entry.IsRunning() {
+				t.Errorf("entry = %+v, want running entry %d", entry, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestPatchTimeEntries(t *testing.T) {
+	toggl := newTestToggl(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v9/workspaces/7/time_entries/1,2" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		want := `[{"op":"replace","path":"/billable","value":false},{"op":"remove","path":"/project_id"}]`
+		if string(body) != want {
+			t.Errorf("body = %s, want %s", body, want)
+		}
+		fmt.Fprint(w, `{"success": [1], "failure": [{"id": 2, "message": "not found"}]}`)
+	})
+
+	result, err := toggl.PatchTimeEntries(context.Background(), 7, []int64{1, 2}, []PatchOperation{
+		{Op: "replace", Path: "/billable", Value: false},
+		{Op: "remove", Path: "/project_id"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Success) != 1 || len(result.Failure) != 1 || result.Failure[0].ID != 2 {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestListProjectsReadsAllPages(t *testing.T) {
+	toggl := newTestToggl(t, func(w http.ResponseWriter, r *http.Request) {
+		if active := r.URL.Query().Get("active"); active != "both" {
+			t.Errorf("active = %s, want both", active)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		count := projectsPageSize
+		if page == 2 {
+			count = 1
+		}
+		fmt.Fprint(w, "[")
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id": %d}`, page*1000+i)
+		}
+		fmt.Fprint(w, "]")
+	})
+
+	projects, err := toggl.ListAllProjects(context.Background(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != projectsPageSize+1 {
+		t.Errorf("listed %d projects, want %d", len(projects), projectsPageSize+1)
+	}
+}
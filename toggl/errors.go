@@ -1,5 +1,7 @@
 package toggl
 
+import "fmt"
+
 type TelegramTimeoutError struct {
 }
 
@@ -11,3 +13,15 @@ func (e *TelegramTimeoutError) Is(target error) bool {
 	_, ok := target.(*TelegramTimeoutError)
 	return ok
 }
+
+// APIError is returned when Toggl responds with a non-2xx status code.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("toggl request failed, method=%s url=%s code=%d body=%s", e.Method, e.URL, e.StatusCode, e.Body)
+}
@@ -0,0 +1,152 @@
+package toggl
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type TimeEntry struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	ProjectID   *int64    `json:"project_id"`
+	TaskID      *int64    `json:"task_id"`
+	UserID      int64     `json:"user_id"`
+	Billable    bool      `json:"billable"`
+	Start       time.Time `json:"start"`
+	// Stop is nil while the entry is running.
+	Stop *time.Time `json:"stop"`
+	// Duration is in seconds, negative while the entry is running.
+	Duration    int64     `json:"duration"`
+	Description string    `json:"description"`
+	Tags        []string  `json:"tags"`
+	TagIDs      []int64   `json:"tag_ids"`
+	At          time.Time `json:"at"`
+}
+
+func (e TimeEntry) IsRunning() bool {
+	return e.Stop == nil
+}
+
+// CreateTimeEntryRequest creates a running entry when Stop is nil and Duration is -1.
+type CreateTimeEntryRequest struct {
+	Description string     `json:"description"`
+	CreatedWith string     `json:"created_with"`
+	Duration    int64      `json:"duration"`
+	Start       time.Time  `json:"start"`
+	Stop        *time.Time `json:"stop,omitempty"`
+	ProjectID   int64      `json:"project_id,omitempty"`
+	TaskID      int64      `json:"task_id,omitempty"`
+	// Tags are created in the workspace if they do not exist yet.
+	Tags        []string `json:"tags,omitempty"`
+	TagIDs      []int64  `json:"tag_ids,omitempty"`
+	Billable    bool     `json:"billable,omitempty"`
+	WorkspaceID int64    `json:"workspace_id"`
+}
+
+// UpdateTimeEntryRequest changes only the fields that are set.
+type UpdateTimeEntryRequest struct {
+	Description *string    `json:"description,omitempty"`
+	Duration    *int64     `json:"duration,omitempty"`
+	Start       *time.Time `json:"start,omitempty"`
+	Stop        *time.Time `json:"stop,omitempty"`
+	ProjectID   *int64     `json:"project_id,omitempty"`
+	TaskID      *int64     `json:"task_id,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	TagIDs      []int64    `json:"tag_ids,omitempty"`
+	Billable    *bool      `json:"billable,omitempty"`
+}
+
+// PatchOperation is a JSON Patch operation used by PatchTimeEntries, e.g. {"add", "/tags", []string{"deep-work"}}.
+// Value is sent as is, including false, 0 and null, except for "remove" operations which have no value.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+
+	type operation PatchOperation
+	return json.Marshal(operation(o))
+}
+
+type PatchResult struct {
+	Success []int64 `json:"success"`
+	Failure []struct {
+		ID      int64  `json:"id"`
+		Message string `json:"message"`
+	} `json:"failure"`
+}
+
+type Project struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	ClientID    *int64    `json:"client_id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"`
+	Active      bool      `json:"active"`
+	IsPrivate   bool      `json:"is_private"`
+	Billable    *bool     `json:"billable"`
+	At          time.Time `json:"at"`
+}
+
+// ProjectRequest creates or updates a project, unset fields keep Toggl defaults or current values.
+type ProjectRequest struct {
+	Name      string `json:"name,omitempty"`
+	ClientID  *int64 `json:"client_id,omitempty"`
+	Color     string `json:"color,omitempty"`
+	Active    *bool  `json:"active,omitempty"`
+	IsPrivate *bool  `json:"is_private,omitempty"`
+}
+
+type Client struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"wid"`
+	Name        string    `json:"name"`
+	Archived    bool      `json:"archived"`
+	At          time.Time `json:"at"`
+}
+
+type Tag struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	Name        string    `json:"name"`
+	At          time.Time `json:"at"`
+}
+
+type Task struct {
+	ID               int64     `json:"id"`
+	WorkspaceID      int64     `json:"workspace_id"`
+	ProjectID        int64     `json:"project_id"`
+	Name             string    `json:"name"`
+	Active           bool      `json:"active"`
+	EstimatedSeconds int64     `json:"estimated_seconds"`
+	At               time.Time `json:"at"`
+}
+
+// TaskRequest creates or updates a task, unset fields keep Toggl defaults or current values.
+type TaskRequest struct {
+	Name             string `json:"name,omitempty"`
+	Active           *bool  `json:"active,omitempty"`
+	EstimatedSeconds int64  `json:"estimated_seconds,omitempty"`
+}
+
+type Workspace struct {
+	ID             int64  `json:"id"`
+	OrganizationID int64  `json:"organization_id"`
+	Name           string `json:"name"`
+}
+
+type Me struct {
+	ID                 int64  `json:"id"`
+	Email              string `json:"email"`
+	Fullname           string `json:"fullname"`
+	Timezone           string `json:"timezone"`
+	DefaultWorkspaceID int64  `json:"default_workspace_id"`
+}
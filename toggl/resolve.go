@@ -0,0 +1,348 @@
+package toggl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// nameCacheTTL is how long workspace projects and tags are reused when resolving names.
+const nameCacheTTL = 10 * time.Minute
+
+var (
+	ErrNameNotFound  = errors.New("toggl name not found")
+	ErrAmbiguousName = errors.New("toggl name is ambiguous")
+)
+
+// TimeEntryInput is a time entry as typed by the user, with project and tags given by name.
+type TimeEntryInput struct {
+	// Text is the input as it was typed.
+	Text        string
+	Description string
+	ProjectName string
+	TagNames    []string
+	// RawTags are sent without matching, Toggl creates the ones that do not exist.
+	RawTags []string
+}
+
+// ParseTimeEntryInput splits text like `Write report #ClientX @deep-work` into description, project and tags.
+// Only `#project` and `@tag` words at the end are taken, so `Fix #42 in parser #Work` keeps `#42`
+// in the description. Numeric `#123` is an issue reference rather than a project, and a leading backslash
+// keeps any other trailing word, e.g. `Reply to \@anna`.
+// The last `#project` wins.
+func ParseTimeEntryInput(text string) TimeEntryInput {
+	input := TimeEntryInput{
+		Text:     text,
+		TagNames: make([]string, 0),
+	}
+
+	words := strings.Fields(text)
+	end := len(words)
+	for ; end > 0; end-- {
+		word := words[end-1]
+		if len(word) < 2 || (word[0] != '#' && word[0] != '@') || isIssueReference(word) {
+			break
+		}
+	}
+	for _, word := range words[end:] {
+		if strings.HasPrefix(word, "#") {
+			input.ProjectName = word[1:]
+		} else {
+			input.TagNames = append(input.TagNames, word[1:])
+		}
+	}
+
+	description := make([]string, 0, end)
+	for _, word := range words[:end] {
+		if strings.HasPrefix(word, `\#`) || strings.HasPrefix(word, `\@`) {
+			word = word[1:]
+		}
+		description = append(description, word)
+	}
+	input.Description = strings.Join(description, " ")
+
+	return input
+}
+
+func isIssueReference(word string) bool {
+	_, err := strconv.ParseUint(strings.TrimPrefix(word, "#"), 10, 64)
+	return strings.HasPrefix(word, "#") && err == nil
+}
+
+func (input TimeEntryInput) String() string {
+	s := input.Description
+	if input.ProjectName != "" {
+		s += fmt.Sprintf(", project %s", input.ProjectName)
+	}
+	if len(input.TagNames) > 0 {
+		s += fmt.Sprintf(", tags %s", strings.Join(input.TagNames, ", "))
+	}
+	return s
+}
+
+// StartOptions configures StartTimeEntry.
+type StartOptions struct {
+	// AutoCreate creates projects and tags that do not match any existing name.
+	AutoCreate bool
+	// ExactNames matches project and tag names only ignoring case, for names taken from config
+	// rather than typed by the user.
+	ExactNames bool
+}
+
+// StartTimeEntry starts a running entry, resolving input.ProjectName and input.TagNames against the workspace.
+func (t *Toggl) StartTimeEntry(ctx context.Context, workspaceID int64, input TimeEntryInput, options StartOptions) (*TimeEntry, error) {
+	request := CreateTimeEntryRequest{
+		Description: input.Description,
+		Duration:    -1,
+		Start:       time.Now().UTC().Truncate(time.Second),
+		WorkspaceID: workspaceID,
+	}
+
+	if input.ProjectName != "" {
+		project, err := t.ResolveProject(ctx, workspaceID, input.ProjectName, options)
+		if err != nil {
+			return nil, err
+		}
+		request.ProjectID = project.ID
+	}
+
+	for _, tagName := range input.TagNames {
+		tag, err := t.ResolveTag(ctx, workspaceID, tagName, options)
+		if err != nil {
+			return nil, err
+		}
+		request.TagIDs = append(request.TagIDs, tag.ID)
+	}
+	request.Tags = input.RawTags
+
+	return t.CreateTimeEntry(ctx, request)
+}
+
+// ResolveProject finds an active project by name, see matchName, and creates it if options.AutoCreate is set.
+// An archived project with the same name is not recreated, as Toggl project names are unique in a workspace.
+func (t *Toggl) ResolveProject(ctx context.Context, workspaceID int64, name string, options StartOptions) (*Project, error) {
+	projects, err := t.cachedProjects(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]Project, 0, len(projects))
+	for _, project := range projects {
+		if project.Active {
+			active = append(active, project)
+		}
+	}
+
+	project, err := matchName(active, name, func(p Project) string { return p.Name }, options.ExactNames)
+	if !errors.Is(err, ErrNameNotFound) || !options.AutoCreate {
+		return project, err
+	}
+	for _, project := range projects {
+		if strings.EqualFold(project.Name, name) {
+			return nil, fmt.Errorf("%w: `%s` is archived", ErrNameNotFound, name)
+		}
+	}
+
+	project, err = t.CreateProject(ctx, workspaceID, ProjectRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	t.cache.addProject(t.cacheKey(workspaceID), *project)
+	return project, nil
+}
+
+// ResolveTag finds a tag by name, see matchName, and creates it if options.AutoCreate is set.
+func (t *Toggl) ResolveTag(ctx context.Context, workspaceID int64, name string, options StartOptions) (*Tag, error) {
+	tags, err := t.cachedTags(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := matchName(tags, name, func(tag Tag) string { return tag.Name }, options.ExactNames)
+	if !errors.Is(err, ErrNameNotFound) || !options.AutoCreate {
+		return tag, err
+	}
+
+	tag, err = t.CreateTag(ctx, workspaceID, name)
+	if err != nil {
+		return nil, err
+	}
+	t.cache.addTag(t.cacheKey(workspaceID), *tag)
+	return tag, nil
+}
+
+// matchName finds the item whose name equals name ignoring case, then ignoring case, spaces and punctuation,
+// then the only one starting with or containing it. Names one typo away match if nothing else does.
+// With exact set, only the first criterion is used.
+func matchName[T any](items []T, name string, itemName func(T) string, exact bool) (*T, error) {
+	normalized := normalizeName(name)
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: empty name", ErrNameNotFound)
+	}
+
+	matchers := []func(string) bool{
+		func(s string) bool { return strings.EqualFold(s, name) },
+		func(s string) bool { return normalizeName(s) == normalized },
+		func(s string) bool { return strings.HasPrefix(normalizeName(s), normalized) },
+		func(s string) bool { return strings.Contains(normalizeName(s), normalized) },
+		func(s string) bool { return len(normalized) >= 4 && editDistance(normalizeName(s), normalized) <= 1 },
+	}
+	if exact {
+		matchers = matchers[:1]
+	}
+	for _, matches := range matchers {
+		found := make([]int, 0)
+		for i, item := range items {
+			if matches(itemName(item)) {
+				found = append(found, i)
+			}
+		}
+
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return &items[found[0]], nil
+		default:
+			candidates := make([]string, 0, len(found))
+			for _, i := range found {
+				candidates = append(candidates, itemName(items[i]))
+			}
+			return nil, fmt.Errorf("%w: `%s` matches %s", ErrAmbiguousName, name, strings.Join(candidates, ", "))
+		}
+	}
+
+	return nil, fmt.Errorf("%w: `%s`", ErrNameNotFound, name)
+}
+
+func normalizeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, strings.ToLower(s))
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		cur := make([]int, len(br)+1)
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(br)]
+}
+
+// sharedNameCache is used by all clients, so that a long-running service does not refetch
+// projects and tags for every started entry.
+var sharedNameCache = newNameCache()
+
+// nameCache keeps workspace projects and tags between name lookups. Entries are keyed by API token too,
+// as users of one workspace may see different projects.
+type nameCache struct {
+	mu       sync.Mutex
+	projects map[nameCacheKey]cachedList[Project]
+	tags     map[nameCacheKey]cachedList[Tag]
+}
+
+type nameCacheKey struct {
+	apiToken    string
+	workspaceID int64
+}
+
+type cachedList[T any] struct {
+	items     []T
+	fetchedAt time.Time
+}
+
+func newNameCache() *nameCache {
+	return &nameCache{
+		projects: map[nameCacheKey]cachedList[Project]{},
+		tags:     map[nameCacheKey]cachedList[Tag]{},
+	}
+}
+
+func (t *Toggl) cacheKey(workspaceID int64) nameCacheKey {
+	return nameCacheKey{apiToken: t.apiToken, workspaceID: workspaceID}
+}
+
+// ForgetCachedNames drops the workspace projects and tags cached for name lookups,
+// e.g. after projects were created, renamed or archived.
+func (t *Toggl) ForgetCachedNames(workspaceID int64) {
+	t.cache.mu.Lock()
+	defer t.cache.mu.Unlock()
+
+	delete(t.cache.projects, t.cacheKey(workspaceID))
+	delete(t.cache.tags, t.cacheKey(workspaceID))
+}
+
+func (t *Toggl) cachedProjects(ctx context.Context, workspaceID int64) ([]Project, error) {
+	t.cache.mu.Lock()
+	defer t.cache.mu.Unlock()
+
+	if cached, ok := t.cache.projects[t.cacheKey(workspaceID)]; ok && time.Since(cached.fetchedAt) < nameCacheTTL {
+		return cached.items, nil
+	}
+
+	projects, err := t.ListAllProjects(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	t.cache.projects[t.cacheKey(workspaceID)] = cachedList[Project]{items: projects, fetchedAt: time.Now()}
+	return projects, nil
+}
+
+func (t *Toggl) cachedTags(ctx context.Context, workspaceID int64) ([]Tag, error) {
+	t.cache.mu.Lock()
+	defer t.cache.mu.Unlock()
+
+	if cached, ok := t.cache.tags[t.cacheKey(workspaceID)]; ok && time.Since(cached.fetchedAt) < nameCacheTTL {
+		return cached.items, nil
+	}
+
+	tags, err := t.ListTags(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	t.cache.tags[t.cacheKey(workspaceID)] = cachedList[Tag]{items: tags, fetchedAt: time.Now()}
+	return tags, nil
+}
+
+func (c *nameCache) addProject(key nameCacheKey, project Project) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.projects[key]; ok {
+		cached.items = append(cached.items, project)
+		c.projects[key] = cached
+	}
+}
+
+func (c *nameCache) addTag(key nameCacheKey, tag Tag) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.tags[key]; ok {
+		cached.items = append(cached.items, tag)
+		c.tags[key] = cached
+	}
+}
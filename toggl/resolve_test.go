@@ -0,0 +1,69 @@
+package toggl
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseTimeEntryInput(t *testing.T) {
+	tests := []struct {
+		text            string
+		wantDescription string
+		wantProject     string
+		wantTags        []string
+	}{
+		{text: "Write report #ClientX @deep-work", wantDescription: "Write report", wantProject: "ClientX", wantTags: []string{"deep-work"}},
+		{text: "Fix #123", wantDescription: "Fix #123"},
+		{text: "Fix #123 #Work", wantDescription: "Fix #123", wantProject: "Work"},
+		{text: "Fix #42 in parser #Work", wantDescription: "Fix #42 in parser", wantProject: "Work"},
+		{text: `Fix \#123`, wantDescription: "Fix #123"},
+		{text: "Email @anna about #budget today", wantDescription: "Email @anna about #budget today"},
+		{text: "Plan #A #B", wantDescription: "Plan", wantProject: "B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			input := ParseTimeEntryInput(tt.text)
+			if input.Description != tt.wantDescription || input.ProjectName != tt.wantProject || !slices.Equal(input.TagNames, tt.wantTags) {
+				t.Errorf("got %q, %q, %v, want %q, %q, %v",
+					input.Description, input.ProjectName, input.TagNames, tt.wantDescription, tt.wantProject, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestMatchName(t *testing.T) {
+	names := []string{"Deep Work", "Client X", "Admin"}
+	itself := func(s string) string { return s }
+
+	tests := []struct {
+		name    string
+		exact   bool
+		want    string
+		wantErr error
+	}{
+		{name: "deep work", want: "Deep Work"},
+		{name: "clientx", want: "Client X"},
+		{name: "Adnin", want: "Admin"},
+		{name: "Deep", want: "Deep Work"},
+		{name: "deep work", exact: true, want: "Deep Work"},
+		{name: "Deep", exact: true, wantErr: ErrNameNotFound},
+		{name: "", wantErr: ErrNameNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchName(names, tt.name, itself, tt.exact)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || *got != tt.want {
+				t.Errorf("got %v, %v, want %s", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -1,137 +1,85 @@
 package toggl
 
 import (
-	"bytes"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
-	"strconv"
+	"strings"
 	"time"
+
+	"github.com/valeriikundas/todoist-scripts/telegram"
 )
 
-const telegramBotAPI = "https://api.telegram.org/bot"
+// promptText asks for the missing entry. Replies to it are recognised by IsPromptReply.
+const promptText = "no running Toggl entry. please fill in, optionally with #project and @tags:"
 
-// fixme: generalize this function
-func AskForTogglEntryInTelegram(telegramApiToken string, telegramUserID int) (string, error) {
-	updateURL := telegramBotAPI + telegramApiToken + "/getUpdates"
+const replyTimeout = time.Minute
 
-	queryTime := time.Now().Unix()
+// ErrRepliesHandledByBot is returned when another process, such as cmd/telegram_bot, already polls updates.
+// That process handles the reply to the prompt, see IsPromptReply.
+var ErrRepliesHandledByBot = errors.New("telegram updates are polled by the bot, it handles the reply")
 
-	query := "no running Toggl entry. please fill in:"
-	sendMessage(telegramApiToken, telegramUserID, query)
+// IsPromptReply reports whether message answers the prompt sent by AskForTogglEntryInTelegram.
+func IsPromptReply(message telegram.Message) bool {
+	return message.ReplyToMessage != nil && strings.HasPrefix(message.ReplyToMessage.Text, promptText)
+}
 
-	offsetValue := int64(0)
+// fixme: generalize this function
+// AskForTogglEntryInTelegram waits for a reply like `Write report #ClientX @deep-work` and parses it.
+func AskForTogglEntryInTelegram(telegramApiToken string, telegramUserID int) (TimeEntryInput, error) {
+	tg := telegram.NewTelegram(telegramApiToken)
 
-	// todo: #9 rewrite with telegram webhook
+	queryTime := time.Now().Unix()
 
-	replyChan := make(chan ReplyResult, 1)
-	go telegramWaitForReply(updateURL, offsetValue, queryTime, telegramApiToken, replyChan)
+	err := tg.SendForceReply(telegramUserID, promptText, telegram.ParseModeNone)
+	if err != nil {
+		return TimeEntryInput{}, err
+	}
 
-	select {
-	case res := <-replyChan:
-		return res.message, res.err
-	case <-time.After(time.Minute):
-		return "", &TelegramTimeoutError{}
+	// todo: #9 rewrite with telegram webhook
+	text, err := telegramWaitForReply(tg, queryTime, time.Now().Add(replyTimeout))
+	if err != nil {
+		return TimeEntryInput{}, err
 	}
+	return ParseTimeEntryInput(text), nil
 }
 
-func telegramWaitForReply(updateURL string, offsetValue int64, queryTime int64, telegramApiToken string, result chan ReplyResult) {
-	for receivedEntry := false; !receivedEntry; {
-		updates, err := getUpdates(updateURL, int(offsetValue))
+// telegramWaitForReply polls updates until a reply to the prompt arrives. Updates up to the reply are confirmed,
+// the ones after it are left for the next poller.
+func telegramWaitForReply(tg telegram.Telegram, queryTime int64, deadline time.Time) (string, error) {
+	offset := 0
+	for time.Now().Before(deadline) {
+		timeoutSeconds := max(int(time.Until(deadline).Seconds()), 1)
+		updates, err := tg.GetUpdates(offset, timeoutSeconds)
+		var sendErr telegram.SendError
+		if errors.As(err, &sendErr) && sendErr.ErrorCode == http.StatusConflict {
+			return "", ErrRepliesHandledByBot
+		}
 		if err != nil {
-			result <- ReplyResult{"", err}
+			return "", err
 		}
 
 		for _, update := range updates {
-			if update.Message.Date < int(queryTime) {
-				log.Printf("message `%s` was sent before interested. skipping...", update.Message.Text)
+			offset = update.ID + 1
+			if update.Message.Date < int(queryTime) || !IsPromptReply(update.Message) {
+				log.Printf("message `%s` is not a reply to the prompt. skipping...", update.Message.Text)
 				continue
 			}
 
-			replyText := fmt.Sprintf("Ok. Recorded: '%s' ", update.Message.Text)
-			sendMessage(telegramApiToken, update.Message.Chat.ID, replyText)
+			_, err = tg.GetUpdates(update.ID+1, 0)
+			if err != nil {
+				log.Printf("error confirming reply, %v", err)
+			}
 
-			receivedEntry = true
-			result <- ReplyResult{update.Message.Text, nil}
+			replyText := fmt.Sprintf("Ok. Recorded: '%s' ", ParseTimeEntryInput(update.Message.Text))
+			err = tg.Send(update.Message.Chat.ID, replyText, telegram.ParseModeNone)
+			if err != nil {
+				log.Println("Error sending message:", err)
+			}
+			return update.Message.Text, nil
 		}
-
-		time.Sleep(time.Second)
-	}
-}
-
-type ReplyResult struct {
-	message string
-	err     error
-}
-
-func getUpdates(url string, offset int) ([]Update, error) {
-	fullUrl := url + "?offset=" + strconv.Itoa(offset) + "&allowed_updates=message&timeout=120"
-	resp, err := http.Get(fullUrl)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
 	}
 
-	var response GetUpdatesResponse
-	err = json.Unmarshal(body, &response)
-	if err != nil {
-		return nil, err
-	}
-
-	return response.Result, nil
-}
-
-func sendMessage(token string, chatID int, text string) {
-	apiURL := telegramBotAPI + token + "/sendMessage"
-	message := SendMessage{
-		ChatID: chatID,
-		Text:   text,
-	}
-
-	messageBytes, err := json.Marshal(message)
-	if err != nil {
-		log.Println("Error marshaling message:", err)
-		return
-	}
-
-	resp, err := http.Post(apiURL, "application/json", bytes.NewBuffer(messageBytes))
-	if err != nil {
-		log.Println("Error sending message:", err)
-		return
-	}
-	defer resp.Body.Close()
-
-	_, _ = io.ReadAll(resp.Body)
-}
-
-type GetUpdatesResponse struct {
-	OK     bool     `json:"ok"`
-	Result []Update `json:"result"`
-}
-
-type Update struct {
-	ID      int     `json:"update_id"`
-	Message Message `json:"message"`
-}
-
-type Message struct {
-	Chat Chat   `json:"chat"`
-	Text string `json:"text"`
-	Date int    `json:"date"`
-}
-
-type Chat struct {
-	ID int `json:"id"`
-}
-
-type SendMessage struct {
-	ChatID int    `json:"chat_id"`
-	Text   string `json:"text"`
+	return "", &TelegramTimeoutError{}
 }
@@ -0,0 +1,97 @@
+package toggl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListTimeEntries returns the user's time entries started in [start, end).
+func (t *Toggl) ListTimeEntries(ctx context.Context, start time.Time, end time.Time) ([]TimeEntry, error) {
+	query := url.Values{}
+	query.Set("start_date", start.UTC().Format(time.RFC3339))
+	query.Set("end_date", end.UTC().Format(time.RFC3339))
+
+	entries := make([]TimeEntry, 0)
+	err := t.do(ctx, http.MethodGet, "/me/time_entries?"+query.Encode(), nil, &entries)
+	return entries, err
+}
+
+// GetCurrentTimeEntry returns the running entry, or nil if there is none.
+func (t *Toggl) GetCurrentTimeEntry(ctx context.Context) (*TimeEntry, error) {
+	var entry *TimeEntry
+	err := t.do(ctx, http.MethodGet, "/me/time_entries/current", nil, &entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.ID == 0 {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+func (t *Toggl) GetTimeEntry(ctx context.Context, id int64) (*TimeEntry, error) {
+	var entry TimeEntry
+	err := t.do(ctx, http.MethodGet, fmt.Sprintf("/me/time_entries/%d", id), nil, &entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// CreateTimeEntry creates an entry in request.WorkspaceID, CreatedWith defaults to this project's name.
+func (t *Toggl) CreateTimeEntry(ctx context.Context, request CreateTimeEntryRequest) (*TimeEntry, error) {
+	if request.CreatedWith == "" {
+		request.CreatedWith = createdWith
+	}
+
+	var entry TimeEntry
+	err := t.do(ctx, http.MethodPost, fmt.Sprintf("/workspaces/%d/time_entries", request.WorkspaceID), request, &entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (t *Toggl) UpdateTimeEntry(ctx context.Context, workspaceID int64, id int64, request UpdateTimeEntryRequest) (*TimeEntry, error) {
+	var entry TimeEntry
+	err := t.do(ctx, http.MethodPut, fmt.Sprintf("/workspaces/%d/time_entries/%d", workspaceID, id), request, &entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (t *Toggl) StopTimeEntry(ctx context.Context, workspaceID int64, id int64) (*TimeEntry, error) {
+	var entry TimeEntry
+	err := t.do(ctx, http.MethodPatch, fmt.Sprintf("/workspaces/%d/time_entries/%d/stop", workspaceID, id), nil, &entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (t *Toggl) DeleteTimeEntry(ctx context.Context, workspaceID int64, id int64) error {
+	return t.do(ctx, http.MethodDelete, fmt.Sprintf("/workspaces/%d/time_entries/%d", workspaceID, id), nil, nil)
+}
+
+// PatchTimeEntries applies operations to several entries in one request. Entries that could not be patched
+// are listed in PatchResult.Failure rather than returned as an error.
+func (t *Toggl) PatchTimeEntries(ctx context.Context, workspaceID int64, ids []int64, operations []PatchOperation) (*PatchResult, error) {
+	idStrings := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idStrings = append(idStrings, strconv.FormatInt(id, 10))
+	}
+
+	var result PatchResult
+	path := fmt.Sprintf("/workspaces/%d/time_entries/%s", workspaceID, strings.Join(idStrings, ","))
+	err := t.do(ctx, http.MethodPatch, path, operations, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
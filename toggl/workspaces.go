@@ -0,0 +1,187 @@
+package toggl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func (t *Toggl) GetMe(ctx context.Context) (*Me, error) {
+	var me Me
+	err := t.do(ctx, http.MethodGet, "/me", nil, &me)
+	if err != nil {
+		return nil, err
+	}
+	return &me, nil
+}
+
+func (t *Toggl) ListWorkspaces(ctx context.Context) ([]Workspace, error) {
+	workspaces := make([]Workspace, 0)
+	err := t.do(ctx, http.MethodGet, "/me/workspaces", nil, &workspaces)
+	return workspaces, err
+}
+
+func (t *Toggl) GetWorkspace(ctx context.Context, workspaceID int64) (*Workspace, error) {
+	var workspace Workspace
+	err := t.do(ctx, http.MethodGet, fmt.Sprintf("/workspaces/%d", workspaceID), nil, &workspace)
+	if err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// projectsPageSize is the largest page the projects endpoint returns.
+const projectsPageSize = 200
+
+// ListProjects reads all pages of active workspace projects.
+func (t *Toggl) ListProjects(ctx context.Context, workspaceID int64) ([]Project, error) {
+	return t.listProjects(ctx, workspaceID, "true")
+}
+
+// ListAllProjects reads all pages of workspace projects, including archived ones.
+func (t *Toggl) ListAllProjects(ctx context.Context, workspaceID int64) ([]Project, error) {
+	return t.listProjects(ctx, workspaceID, "both")
+}
+
+func (t *Toggl) listProjects(ctx context.Context, workspaceID int64, active string) ([]Project, error) {
+	query := url.Values{}
+	query.Set("active", active)
+	query.Set("per_page", strconv.Itoa(projectsPageSize))
+
+	projects := make([]Project, 0)
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		pageProjects := make([]Project, 0)
+		err := t.do(ctx, http.MethodGet, fmt.Sprintf("/workspaces/%d/projects?%s", workspaceID, query.Encode()), nil, &pageProjects)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, pageProjects...)
+		if len(pageProjects) < projectsPageSize {
+			return projects, nil
+		}
+	}
+}
+
+func (t *Toggl) GetProject(ctx context.Context, workspaceID int64, id int64) (*Project, error) {
+	var project Project
+	err := t.do(ctx, http.MethodGet, fmt.Sprintf("/workspaces/%d/projects/%d", workspaceID, id), nil, &project)
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (t *Toggl) CreateProject(ctx context.Context, workspaceID int64, request ProjectRequest) (*Project, error) {
+	var project Project
+	err := t.do(ctx, http.MethodPost, fmt.Sprintf("/workspaces/%d/projects", workspaceID), request, &project)
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (t *Toggl) UpdateProject(ctx context.Context, workspaceID int64, id int64, request ProjectRequest) (*Project, error) {
+	var project Project
+	err := t.do(ctx, http.MethodPut, fmt.Sprintf("/workspaces/%d/projects/%d", workspaceID, id), request, &project)
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (t *Toggl) DeleteProject(ctx context.Context, workspaceID int64, id int64) error {
+	return t.do(ctx, http.MethodDelete, fmt.Sprintf("/workspaces/%d/projects/%d", workspaceID, id), nil, nil)
+}
+
+func (t *Toggl) ListClients(ctx context.Context, workspaceID int64) ([]Client, error) {
+	clients := make([]Client, 0)
+	err := t.do(ctx, http.MethodGet, fmt.Sprintf("/workspaces/%d/clients", workspaceID), nil, &clients)
+	return clients, err
+}
+
+func (t *Toggl) CreateClient(ctx context.Context, workspaceID int64, name string) (*Client, error) {
+	var client Client
+	body := map[string]any{"name": name, "wid": workspaceID}
+	err := t.do(ctx, http.MethodPost, fmt.Sprintf("/workspaces/%d/clients", workspaceID), body, &client)
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (t *Toggl) UpdateClient(ctx context.Context, workspaceID int64, id int64, name string) (*Client, error) {
+	var client Client
+	body := map[string]any{"name": name}
+	err := t.do(ctx, http.MethodPut, fmt.Sprintf("/workspaces/%d/clients/%d", workspaceID, id), body, &client)
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (t *Toggl) DeleteClient(ctx context.Context, workspaceID int64, id int64) error {
+	return t.do(ctx, http.MethodDelete, fmt.Sprintf("/workspaces/%d/clients/%d", workspaceID, id), nil, nil)
+}
+
+func (t *Toggl) ListTags(ctx context.Context, workspaceID int64) ([]Tag, error) {
+	tags := make([]Tag, 0)
+	err := t.do(ctx, http.MethodGet, fmt.Sprintf("/workspaces/%d/tags", workspaceID), nil, &tags)
+	return tags, err
+}
+
+func (t *Toggl) CreateTag(ctx context.Context, workspaceID int64, name string) (*Tag, error) {
+	var tag Tag
+	body := map[string]any{"name": name, "workspace_id": workspaceID}
+	err := t.do(ctx, http.MethodPost, fmt.Sprintf("/workspaces/%d/tags", workspaceID), body, &tag)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (t *Toggl) UpdateTag(ctx context.Context, workspaceID int64, id int64, name string) (*Tag, error) {
+	var tag Tag
+	body := map[string]any{"name": name, "workspace_id": workspaceID}
+	err := t.do(ctx, http.MethodPut, fmt.Sprintf("/workspaces/%d/tags/%d", workspaceID, id), body, &tag)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (t *Toggl) DeleteTag(ctx context.Context, workspaceID int64, id int64) error {
+	return t.do(ctx, http.MethodDelete, fmt.Sprintf("/workspaces/%d/tags/%d", workspaceID, id), nil, nil)
+}
+
+func (t *Toggl) ListTasks(ctx context.Context, workspaceID int64, projectID int64) ([]Task, error) {
+	tasks := make([]Task, 0)
+	err := t.do(ctx, http.MethodGet, fmt.Sprintf("/workspaces/%d/projects/%d/tasks", workspaceID, projectID), nil, &tasks)
+	return tasks, err
+}
+
+func (t *Toggl) CreateTask(ctx context.Context, workspaceID int64, projectID int64, request TaskRequest) (*Task, error) {
+	var task Task
+	path := fmt.Sprintf("/workspaces/%d/projects/%d/tasks", workspaceID, projectID)
+	err := t.do(ctx, http.MethodPost, path, request, &task)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (t *Toggl) UpdateTask(ctx context.Context, workspaceID int64, projectID int64, id int64, request TaskRequest) (*Task, error) {
+	var task Task
+	path := fmt.Sprintf("/workspaces/%d/projects/%d/tasks/%d", workspaceID, projectID, id)
+	err := t.do(ctx, http.MethodPut, path, request, &task)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (t *Toggl) DeleteTask(ctx context.Context, workspaceID int64, projectID int64, id int64) error {
+	return t.do(ctx, http.MethodDelete, fmt.Sprintf("/workspaces/%d/projects/%d/tasks/%d", workspaceID, projectID, id), nil, nil)
+}
@@ -2,9 +2,12 @@ package utils
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/aws/jsii-runtime-go"
 	"log"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -30,6 +33,19 @@ func ReadConfig(configs ...string) *map[string]*string {
 			}
 			return &map[string]*string{
 				"ExcludeFromZeroProjectsList": jsii.String(excludeFromZeroProjectsList),
+				"NextActionProjectLimits":     jsii.String(os.Getenv("NextActionProjectLimits")),
+				"NextActionSectionLimits":     jsii.String(os.Getenv("NextActionSectionLimits")),
+				"CheckZeroPerSection":         jsii.String(os.Getenv("CheckZeroPerSection")),
+				"EnforceNextActionLimits":     jsii.String(os.Getenv("EnforceNextActionLimits")),
+				"DemoteLabel":                 jsii.String(os.Getenv("DemoteLabel")),
+				"PromoteNextActions":          jsii.String(os.Getenv("PromoteNextActions")),
+				"NextActionGlobalLimit":       jsii.String(os.Getenv("NextActionGlobalLimit")),
+				"NextActionAreaLimits":        jsii.String(os.Getenv("NextActionAreaLimits")),
+				"TaskLintRules":               jsii.String(os.Getenv("TaskLintRules")),
+				"TaskLintLanguages":           jsii.String(os.Getenv("TaskLintLanguages")),
+				"TaskLintVerbs":               jsii.String(os.Getenv("TaskLintVerbs")),
+				"ProjectLint":                 jsii.String(os.Getenv("ProjectLint")),
+				"InboxProtectedPriority":      jsii.String(os.Getenv("InboxProtectedPriority")),
 			}
 		} else {
 			panic(err)
@@ -44,6 +60,24 @@ func ReadConfig(configs ...string) *map[string]*string {
 	decoder := json.NewDecoder(file)
 	var config struct {
 		ExcludeFromZeroProjectsList []string
+		NextActionProjectLimits     map[string]int
+		NextActionSectionLimits     map[string]int
+		CheckZeroPerSection         bool
+		// EnforceNextActionLimits is an ordering such as "priority,due,age", empty to only report.
+		EnforceNextActionLimits string
+		DemoteLabel             string
+		// PromoteNextActions is "order" or "priority", empty to only report projects without @next_action.
+		PromoteNextActions string
+		// NextActionGlobalLimit caps @next_action tasks across all projects, 0 disables the cap.
+		NextActionGlobalLimit int
+		NextActionAreaLimits  map[string]int
+		// TaskLintRules lists enabled lint rules such as ["verb", "length"] or ["all"], empty disables linting.
+		TaskLintRules     []string
+		TaskLintLanguages []string
+		// TaskLintVerbs replaces the verb list per language, e.g. {"en": ["call", "email"]}.
+		TaskLintVerbs map[string][]string
+		// ProjectLint is passed on as JSON, see todoist.ProjectLintConfig.
+		ProjectLint json.RawMessage
 	}
 	err = decoder.Decode(&config)
 	must(err)
@@ -51,10 +85,98 @@ func ReadConfig(configs ...string) *map[string]*string {
 	zeroProjectsListJoined := strings.Join(config.ExcludeFromZeroProjectsList, ";")
 	envVars := &map[string]*string{
 		"ExcludeFromZeroProjectsList": jsii.String(zeroProjectsListJoined),
+		"NextActionProjectLimits":     jsii.String(JoinProjectLimits(config.NextActionProjectLimits)),
+		"NextActionSectionLimits":     jsii.String(JoinProjectLimits(config.NextActionSectionLimits)),
+		"CheckZeroPerSection":         jsii.String(strconv.FormatBool(config.CheckZeroPerSection)),
+		"EnforceNextActionLimits":     jsii.String(config.EnforceNextActionLimits),
+		"DemoteLabel":                 jsii.String(config.DemoteLabel),
+		"PromoteNextActions":          jsii.String(config.PromoteNextActions),
+		"NextActionGlobalLimit":       jsii.String(strconv.Itoa(config.NextActionGlobalLimit)),
+		"NextActionAreaLimits":        jsii.String(JoinProjectLimits(config.NextActionAreaLimits)),
+		"TaskLintRules":               jsii.String(strings.Join(config.TaskLintRules, ",")),
+		"TaskLintLanguages":           jsii.String(strings.Join(config.TaskLintLanguages, ",")),
+		"TaskLintVerbs":               jsii.String(joinLintVerbs(config.TaskLintVerbs)),
+		"ProjectLint":                 jsii.String(string(config.ProjectLint)),
 	}
 	return envVars
 }
 
+// JoinProjectLimits encodes per-project limits as `name=limit` pairs separated by `;`.
+func JoinProjectLimits(limits map[string]int) string {
+	pairs := make([]string, 0, len(limits))
+	for name, limit := range limits {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, limit))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ";")
+}
+
+// ParseProjectLimits decodes the output of JoinProjectLimits.
+func ParseProjectLimits(s string) (map[string]int, error) {
+	limits := map[string]int{}
+	if s == "" {
+		return limits, nil
+	}
+
+	for _, pair := range strings.Split(s, ";") {
+		i := strings.LastIndex(pair, "=")
+		if i == -1 {
+			return nil, fmt.Errorf("invalid project limit `%s`, expected `name=limit`", pair)
+		}
+		limit, err := strconv.Atoi(pair[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid project limit `%s`: %w", pair, err)
+		}
+		limits[pair[:i]] = limit
+	}
+	return limits, nil
+}
+
+// joinLintVerbs joins verb lists into the `en=call,email;uk=купити` format read by todoist.ParseTaskLintConfig.
+func joinLintVerbs(verbs map[string][]string) string {
+	languages := make([]string, 0, len(verbs))
+	for language := range verbs {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	pairs := make([]string, 0, len(languages))
+	for _, language := range languages {
+		pairs = append(pairs, language+"="+strings.Join(verbs[language], ","))
+	}
+	return strings.Join(pairs, ";")
+}
+
+// ParseNameMap decodes `;`-separated `from=to` pairs, e.g. a Todoist to Toggl project map.
+func ParseNameMap(s string) (map[string]string, error) {
+	names := map[string]string{}
+	if s == "" {
+		return names, nil
+	}
+
+	for _, pair := range strings.Split(s, ";") {
+		from, to, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid name mapping `%s`, expected `from=to`", pair)
+		}
+		names[from] = to
+	}
+	return names, nil
+}
+
+// ParseGlobalLimit parses the global @next_action cap, an empty string disables it.
+func ParseGlobalLimit(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid global limit `%s`: %w", s, err)
+	}
+	return limit, nil
+}
+
 func must(err error) {
 	if err != nil {
 		panic(err)
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WorkingHours is the daily window from StartHour to EndHour (exclusive) in the user's timezone.
+type WorkingHours struct {
+	StartHour int
+	EndHour   int
+}
+
+var DefaultWorkingHours = WorkingHours{StartHour: 8, EndHour: 24}
+
+// Contains reports whether t, converted to the user's timezone by the caller, is within working hours.
+func (w WorkingHours) Contains(t time.Time) bool {
+	return t.Hour() >= w.StartHour && t.Hour() < w.EndHour
+}
+
+// ParseWorkingHours parses "8-24". An empty string returns DefaultWorkingHours.
+func ParseWorkingHours(s string) (WorkingHours, error) {
+	if s == "" {
+		return DefaultWorkingHours, nil
+	}
+
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return WorkingHours{}, fmt.Errorf("invalid working hours `%s`, expected start-end", s)
+	}
+	startHour, err := strconv.Atoi(strings.TrimSpace(start))
+	if err != nil {
+		return WorkingHours{}, fmt.Errorf("invalid working hours `%s`: %w", s, err)
+	}
+	endHour, err := strconv.Atoi(strings.TrimSpace(end))
+	if err != nil {
+		return WorkingHours{}, fmt.Errorf("invalid working hours `%s`: %w", s, err)
+	}
+	if startHour < 0 || endHour > 24 || startHour >= endHour {
+		return WorkingHours{}, fmt.Errorf("invalid working hours `%s`", s)
+	}
+
+	return WorkingHours{StartHour: startHour, EndHour: endHour}, nil
+}